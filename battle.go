@@ -0,0 +1,201 @@
+package gohbem
+
+// battleMaxTurns limits SimulateBattle to 4 minutes of 0.5 second turns.
+const battleMaxTurns = 480
+
+// battleMaxEnergy is maximum energy stored by Pokemon during battle.
+const battleMaxEnergy = 100
+
+// battler is holding SimulateBattle state of one Combatant.
+type battler struct {
+	attack       float64
+	defense      float64
+	hp           int
+	energy       int
+	shields      int
+	cooldown     int
+	fastMove     Move
+	fastMoveId   int
+	chargedMoves []int
+	types        []int
+	combatant    *Combatant
+}
+
+// BattlePokemon returns BattlePokemon of QueryPvPRank entry with provided IVs.
+func (e PokemonEntry) BattlePokemon(attack, defense, stamina int) BattlePokemon {
+	return BattlePokemon{
+		Pokemon:   e.Pokemon,
+		Form:      e.Form,
+		Evolution: e.Evolution,
+		Attack:    attack,
+		Defense:   defense,
+		Stamina:   stamina,
+		Level:     e.Level,
+	}
+}
+
+// newBattler validates Combatant moves and calculates its battle stats.
+func (o *Ohbem) newBattler(c *Combatant) (*battler, error) {
+	if (c.Attack < 0 || c.Attack > 15) || (c.Defense < 0 || c.Defense > 15) || (c.Stamina < 0 || c.Stamina > 15) || c.Level < 1 {
+		return nil, ErrQueryInputOutOfRange
+	}
+	stats, err := o.resolveStats(c.Pokemon, c.Form, c.Evolution)
+	if err != nil {
+		return nil, err
+	}
+	fastMove, ok := o.PokemonData.Moves[c.FastMove]
+	if !ok {
+		return nil, ErrMissingMove
+	}
+	if fastMove.Turns <= 0 {
+		return nil, ErrBattleMoveInvalid
+	}
+	for _, moveId := range c.ChargedMoves {
+		move, ok := o.PokemonData.Moves[moveId]
+		if !ok {
+			return nil, ErrMissingMove
+		}
+		if move.Turns > 0 || move.Energy <= 0 {
+			return nil, ErrBattleMoveInvalid
+		}
+	}
+	multiplier := calculateCpMultiplier(c.Level)
+	return &battler{
+		attack:       float64(stats.Attack+c.Attack) * multiplier,
+		defense:      float64(stats.Defense+c.Defense) * multiplier,
+		hp:           calculateHp(&stats, c.Stamina, c.Level),
+		shields:      c.Shields,
+		fastMove:     fastMove,
+		fastMoveId:   c.FastMove,
+		chargedMoves: c.ChargedMoves,
+		types:        o.resolveTypes(c.Pokemon, c.Form),
+		combatant:    c,
+	}, nil
+}
+
+// damage calculates damage of move used by attacker against defender, including STAB and type effectiveness.
+func (o *Ohbem) damage(move Move, attacker, defender *battler) int {
+	multiplier := calculateTypeEffectiveness(o.PokemonData.TypeEffectiveness, move.Type, defender.types)
+	if containsInt(attacker.types, move.Type) {
+		multiplier *= stabMultiplier
+	}
+	return calculateDamage(move.Power, attacker.attack, defender.defense, multiplier)
+}
+
+// chooseChargedMove picks charged move to use this turn, or 0 when attacker should keep farming energy.
+// Affordable move knocking out defender is used first, otherwise move with best damage per energy is used once affordable.
+func (o *Ohbem) chooseChargedMove(attacker, defender *battler) int {
+	best, bestRatio := 0, 0.0
+	for _, moveId := range attacker.chargedMoves {
+		move := o.PokemonData.Moves[moveId]
+		dmg := o.damage(move, attacker, defender)
+		if move.Energy <= attacker.energy && defender.shields == 0 && dmg >= defender.hp {
+			return moveId
+		}
+		if ratio := float64(dmg) / float64(move.Energy); ratio > bestRatio {
+			best, bestRatio = moveId, ratio
+		}
+	}
+	if best != 0 && o.PokemonData.Moves[best].Energy <= attacker.energy {
+		return best
+	}
+	return 0
+}
+
+// SimulateBattle Simulate deterministic one-on-one PvP battle between two Combatants.
+// Turns are 0.5 seconds long, fast move damage lands when the move finishes and shields are always used when available.
+// When both Combatants use charged move in the same turn, CMP tie is won by higher attack (first Combatant on equal attack).
+func (o *Ohbem) SimulateBattle(first Combatant, second Combatant) (BattleResult, error) {
+	result := BattleResult{Winner: -1}
+
+	if err := safetyCheck(o); err != nil {
+		return result, err
+	}
+
+	var battlers [2]*battler
+	for ix, c := range []*Combatant{&first, &second} {
+		b, err := o.newBattler(c)
+		if err != nil {
+			return result, err
+		}
+		battlers[ix] = b
+		result.MaxHp[ix] = b.hp
+	}
+
+	turn := 0
+	for ; turn < battleMaxTurns && battlers[0].hp > 0 && battlers[1].hp > 0; turn++ {
+		// charged moves are resolved first, in CMP order
+		order := [2]int{0, 1}
+		if battlers[1].attack > battlers[0].attack {
+			order = [2]int{1, 0}
+		}
+		var charged [2]bool
+		for _, ix := range order {
+			attacker, defender := battlers[ix], battlers[1-ix]
+			if attacker.cooldown > 0 || attacker.hp <= 0 || defender.hp <= 0 {
+				continue
+			}
+			moveId := o.chooseChargedMove(attacker, defender)
+			if moveId == 0 {
+				continue
+			}
+			move := o.PokemonData.Moves[moveId]
+			attacker.energy -= move.Energy
+			event := BattleEvent{Turn: turn, Attacker: ix, Move: moveId, Charged: true, Damage: o.damage(move, attacker, defender)}
+			if defender.shields > 0 {
+				defender.shields--
+				event.Shielded = true
+				event.Damage = 1
+			}
+			defender.hp -= event.Damage
+			event.Energy = attacker.energy
+			event.Hp = max(defender.hp, 0)
+			result.Timeline = append(result.Timeline, event)
+			charged[ix] = true
+		}
+
+		// free Combatants start their fast moves
+		for ix, attacker := range battlers {
+			if attacker.cooldown == 0 && !charged[ix] && attacker.hp > 0 {
+				attacker.cooldown = attacker.fastMove.Turns
+			}
+		}
+
+		// finished fast moves deal damage, at the same time for both Combatants
+		var damages [2]int
+		for ix, attacker := range battlers {
+			if attacker.cooldown == 0 {
+				continue
+			}
+			attacker.cooldown--
+			if attacker.cooldown > 0 || attacker.hp <= 0 {
+				continue
+			}
+			defender := battlers[1-ix]
+			damages[ix] = o.damage(attacker.fastMove, attacker, defender)
+			attacker.energy = min(attacker.energy+attacker.fastMove.Energy, battleMaxEnergy)
+			result.Timeline = append(result.Timeline, BattleEvent{
+				Turn:     turn,
+				Attacker: ix,
+				Move:     attacker.fastMoveId,
+				Damage:   damages[ix],
+				Energy:   attacker.energy,
+				Hp:       max(defender.hp-damages[ix], 0),
+			})
+		}
+		for ix, dmg := range damages {
+			battlers[1-ix].hp -= dmg
+		}
+	}
+
+	result.Turns = turn
+	for ix, b := range battlers {
+		result.Hp[ix] = max(b.hp, 0)
+	}
+	if result.Hp[0] > 0 && result.Hp[1] == 0 {
+		result.Winner = 0
+	} else if result.Hp[1] > 0 && result.Hp[0] == 0 {
+		result.Winner = 1
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSimulateBattle(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-moves-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	azumarill := Combatant{
+		BattlePokemon: BattlePokemon{Pokemon: 184, Attack: 8, Defense: 15, Stamina: 15, Level: 40},
+		FastMove:      237,
+		ChargedMoves:  []int{39, 387},
+	}
+	medicham := Combatant{
+		BattlePokemon: BattlePokemon{Pokemon: 308, Attack: 15, Defense: 15, Stamina: 15, Level: 49},
+		FastMove:      224,
+		ChargedMoves:  []int{40, 108},
+	}
+	talonflame := Combatant{
+		BattlePokemon: BattlePokemon{Pokemon: 663, Attack: 0, Defense: 15, Stamina: 15, Level: 22},
+		FastMove:      269,
+		ChargedMoves:  []int{298, 101},
+	}
+
+	for _, shields := range []int{0, 1, 2} {
+		for _, pair := range [][2]Combatant{{azumarill, medicham}, {medicham, talonflame}, {talonflame, azumarill}} {
+			a, b := pair[0], pair[1]
+			a.Shields, b.Shields = shields, shields
+			t.Run(fmt.Sprintf("%d-%d-%d", a.Pokemon, b.Pokemon, shields), func(t *testing.T) {
+				result, err := ohbem.SimulateBattle(a, b)
+				if err != nil {
+					t.Fatalf("got %v", err)
+				}
+				swapped, _ := ohbem.SimulateBattle(b, a)
+				if result.Winner != -1 && swapped.Winner != 1-result.Winner {
+					t.Errorf("got winner %d and swapped winner %d", result.Winner, swapped.Winner)
+				}
+				if result.Winner == -1 || result.Hp[result.Winner] <= 0 || result.Hp[1-result.Winner] != 0 {
+					t.Errorf("got %+v, want single winner", result)
+				}
+				var dealt [2]int
+				var shielded [2]int
+				for _, event := range result.Timeline {
+					dealt[event.Attacker] += event.Damage
+					if event.Shielded {
+						shielded[1-event.Attacker]++
+					}
+					if event.Energy < 0 || event.Energy > 100 {
+						t.Errorf("got energy %d out of range", event.Energy)
+					}
+				}
+				for ix := range dealt {
+					if result.MaxHp[1-ix]-min(dealt[ix], result.MaxHp[1-ix]) != result.Hp[1-ix] || shielded[ix] > shields {
+						t.Errorf("got %+v, timeline does not add up", result)
+					}
+				}
+			})
+		}
+	}
+
+	var tests = []struct {
+		first  Combatant
+		second Combatant
+		winner int
+		hp     [2]int
+		turns  int
+	}{
+		{azumarill, medicham, 0, [2]int{23, 0}, 35},
+	}
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			result, _ := ohbem.SimulateBattle(test.first, test.second)
+			if result.Winner != test.winner || result.Hp != test.hp || result.Turns != test.turns {
+				t.Errorf("got %d %v %d, want %+v", result.Winner, result.Hp, result.Turns, test)
+			}
+		})
+	}
+
+	invalid := azumarill
+	invalid.FastMove = 39
+	if _, err := ohbem.SimulateBattle(invalid, medicham); err != ErrBattleMoveInvalid {
+		t.Errorf("got %v, want %v", err, ErrBattleMoveInvalid)
+	}
+	invalid.FastMove = 1
+	if _, err := ohbem.SimulateBattle(invalid, medicham); err != ErrMissingMove {
+		t.Errorf("got %v, want %v", err, ErrMissingMove)
+	}
+}
+
+func BenchmarkSimulateBattle(b *testing.B) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	_ = ohbem.LoadPokemonData("./test/master-moves-test.json")
+	azumarill := Combatant{
+		BattlePokemon: BattlePokemon{Pokemon: 184, Attack: 8, Defense: 15, Stamina: 15, Level: 40},
+		FastMove:      237,
+		ChargedMoves:  []int{39, 387},
+		Shields:       1,
+	}
+	medicham := Combatant{
+		BattlePokemon: BattlePokemon{Pokemon: 308, Attack: 15, Defense: 15, Stamina: 15, Level: 49},
+		FastMove:      224,
+		ChargedMoves:  []int{40, 108},
+		Shields:       1,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ohbem.SimulateBattle(azumarill, medicham)
+	}
+}
@@ -0,0 +1,127 @@
+package gohbem
+
+import "sort"
+
+// AnalyzeBreakpoints Compute breakpoints and bulkpoints of Pokémon with its IVs and level against provided opponents.
+// For each opponent report contains damage dealt and taken by the Pokémon, and every damage tier reachable by IV spreads under the same league and level cap.
+func (o *Ohbem) AnalyzeBreakpoints(pokemon BattlePokemon, opponents []Opponent, query BreakpointQuery) ([]BreakpointReport, error) {
+	var result []BreakpointReport
+
+	if err := safetyCheck(o); err != nil {
+		return result, err
+	}
+
+	if (pokemon.Attack < 0 || pokemon.Attack > 15) || (pokemon.Defense < 0 || pokemon.Defense > 15) || (pokemon.Stamina < 0 || pokemon.Stamina > 15) || pokemon.Level < 1 {
+		return result, ErrQueryInputOutOfRange
+	}
+
+	league, ok := o.Leagues[query.League]
+	if !ok {
+		return result, ErrLeagueMissing
+	}
+	league = namedLeague(query.League, league)
+
+	stats, err := o.resolveStats(pokemon.Pokemon, pokemon.Form, pokemon.Evolution)
+	if err != nil {
+		return result, err
+	}
+
+	capped := !league.IsUncapped() && query.Cap != 0
+	var ranks *[4096]int16
+	if capped {
+		combinationIndex, _ := o.calculateAllRanksCompact(&stats, league.Cap, league.levelCaps(o.LevelCaps), league.IvFloor)
+		if combinations, ok := combinationIndex[int(query.Cap)]; ok {
+			ranks = combinations.Combinations
+		}
+	}
+
+	var spreads []IvSpread
+	var spreadStats []PvPRankingStats
+	for a := league.IvFloor; a <= 15; a++ {
+		for d := league.IvFloor; d <= 15; d++ {
+			for s := league.IvFloor; s <= 15; s++ {
+				var stat PvPRankingStats
+				if !capped {
+					multiplier := calculateCpMultiplier(pokemon.Level)
+					stat.Attack = float64(stats.Attack+a) * multiplier
+					stat.Defense = float64(stats.Defense+d) * multiplier
+					stat.Level = pokemon.Level
+					stat.Cp = calculateCp(&stats, a, d, s, pokemon.Level)
+				} else if calculatePvPStat(&stat, &stats, a, d, s, league.Cap, query.Cap, 1) != nil {
+					continue
+				}
+				spread := IvSpread{Attack: a, Defense: d, Stamina: s, Level: stat.Level, Cp: stat.Cp}
+				if ranks != nil {
+					spread.Rank = ranks[(a*16+d)*16+s]
+				}
+				spreads = append(spreads, spread)
+				spreadStats = append(spreadStats, stat)
+			}
+		}
+	}
+
+	multiplier := calculateCpMultiplier(pokemon.Level)
+	attack := float64(stats.Attack+pokemon.Attack) * multiplier
+	defense := float64(stats.Defense+pokemon.Defense) * multiplier
+
+	for _, opponent := range opponents {
+		if opponent.Level < 1 {
+			return result, ErrQueryInputOutOfRange
+		}
+		opponentStats, err := o.resolveStats(opponent.Pokemon, opponent.Form, opponent.Evolution)
+		if err != nil {
+			return result, err
+		}
+		opponentMultiplier := calculateCpMultiplier(opponent.Level)
+		report := BreakpointReport{
+			Opponent:        opponent,
+			OpponentAttack:  float64(opponentStats.Attack+opponent.Attack) * opponentMultiplier,
+			OpponentDefense: float64(opponentStats.Defense+opponent.Defense) * opponentMultiplier,
+			Attack:          attack,
+			Defense:         defense,
+		}
+		report.Damage = calculateDamage(query.Power, attack, report.OpponentDefense, query.Multiplier)
+		report.DamageTaken = calculateDamage(query.OpponentPower, report.OpponentAttack, defense, query.OpponentMultiplier)
+
+		breakpoints := make(map[int]*DamageTier)
+		bulkpoints := make(map[int]*DamageTier)
+		for ix := range spreads {
+			addDamageTier(breakpoints, calculateDamage(query.Power, spreadStats[ix].Attack, report.OpponentDefense, query.Multiplier), spreadStats[ix].Attack, spreads[ix])
+			addDamageTier(bulkpoints, calculateDamage(query.OpponentPower, report.OpponentAttack, spreadStats[ix].Defense, query.OpponentMultiplier), spreadStats[ix].Defense, spreads[ix])
+		}
+		report.Breakpoints = sortDamageTiers(breakpoints, true)
+		report.Bulkpoints = sortDamageTiers(bulkpoints, false)
+		report.Breakpoint = len(report.Breakpoints) != 0 && report.Damage >= report.Breakpoints[0].Damage
+		report.Bulkpoint = len(report.Bulkpoints) != 0 && report.DamageTaken <= report.Bulkpoints[0].Damage
+		result = append(result, report)
+	}
+	return result, nil
+}
+
+// addDamageTier adds spread with its effective stat into tier of provided damage.
+func addDamageTier(tiers map[int]*DamageTier, damage int, stat float64, spread IvSpread) {
+	tier, ok := tiers[damage]
+	if !ok {
+		tier = &DamageTier{Damage: damage, Stat: stat}
+		tiers[damage] = tier
+	}
+	if stat < tier.Stat {
+		tier.Stat = stat
+	}
+	tier.Spreads = append(tier.Spreads, spread)
+}
+
+// sortDamageTiers returns tiers ordered by damage, descending for breakpoints and ascending for bulkpoints.
+func sortDamageTiers(tiers map[int]*DamageTier, descending bool) []DamageTier {
+	result := make([]DamageTier, 0, len(tiers))
+	for _, tier := range tiers {
+		result = append(result, *tier)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if descending {
+			return result[i].Damage > result[j].Damage
+		}
+		return result[i].Damage < result[j].Damage
+	})
+	return result
+}
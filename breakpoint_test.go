@@ -0,0 +1,81 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAnalyzeBreakpoints(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"great": leagues["great"],
+		"tiny":  {Cap: 10},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, 15, 15, 14, 1)
+	entry := entries["great"][0]
+	pokemon := BattlePokemon{Pokemon: entry.Pokemon, Form: entry.Form, Evolution: entry.Evolution, Attack: 15, Defense: 15, Stamina: 14, Level: entry.Level}
+	opponents := []Opponent{{Pokemon: 184, Attack: 8, Defense: 15, Stamina: 15, Level: 40}}
+
+	var tests = []struct {
+		query BreakpointQuery
+		tiers bool
+	}{
+		{BreakpointQuery{League: "great", Cap: entry.Cap, Power: 6, OpponentPower: 8}, true},
+		{BreakpointQuery{League: "great", Power: 6, OpponentPower: 8}, true},
+		{BreakpointQuery{League: "tiny", Cap: 50, Power: 6, OpponentPower: 8}, false},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			reports, err := ohbem.AnalyzeBreakpoints(pokemon, opponents, test.query)
+			if err != nil || len(reports) != 1 {
+				t.Fatalf("got %v %+v, want one report", err, reports)
+			}
+			report := reports[0]
+			if (len(report.Breakpoints) != 0) != test.tiers || (len(report.Bulkpoints) != 0) != test.tiers {
+				t.Fatalf("got %d breakpoint and %d bulkpoint tiers, want tiers %t", len(report.Breakpoints), len(report.Bulkpoints), test.tiers)
+			}
+			if !test.tiers {
+				if report.Breakpoint || report.Bulkpoint {
+					t.Errorf("got %+v, want no breakpoint and bulkpoint without tiers", report)
+				}
+				return
+			}
+			if report.Breakpoint != (report.Damage == report.Breakpoints[0].Damage) || report.Bulkpoint != (report.DamageTaken == report.Bulkpoints[0].Damage) {
+				t.Errorf("got %+v, inconsistent breakpoint flags", report)
+			}
+			for i := 1; i < len(report.Breakpoints); i++ {
+				if report.Breakpoints[i].Damage >= report.Breakpoints[i-1].Damage || report.Breakpoints[i].Stat >= report.Breakpoints[i-1].Stat {
+					t.Errorf("got %+v after %+v, want lower damage and attack", report.Breakpoints[i], report.Breakpoints[i-1])
+				}
+			}
+			for i := 1; i < len(report.Bulkpoints); i++ {
+				if report.Bulkpoints[i].Damage <= report.Bulkpoints[i-1].Damage || report.Bulkpoints[i].Stat >= report.Bulkpoints[i-1].Stat {
+					t.Errorf("got %+v after %+v, want higher damage taken and lower defense", report.Bulkpoints[i], report.Bulkpoints[i-1])
+				}
+			}
+			for _, tier := range report.Breakpoints {
+				for _, spread := range tier.Spreads {
+					if test.query.Cap != 0 && (spread.Rank == 0 || spread.Cp > 1500) {
+						t.Errorf("got %+v, want ranked spread under cap", spread)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := ohbem.AnalyzeBreakpoints(pokemon, opponents, BreakpointQuery{League: "missing"}); err != ErrLeagueMissing {
+		t.Errorf("got %v, want %v", err, ErrLeagueMissing)
+	}
+	if _, err := ohbem.AnalyzeBreakpoints(pokemon, []Opponent{{Pokemon: 99999, Level: 40}}, tests[0].query); err != ErrMissingPokemon {
+		t.Errorf("got %v, want %v", err, ErrMissingPokemon)
+	}
+	if _, err := ohbem.AnalyzeBreakpoints(BattlePokemon{Pokemon: 661, Attack: 16, Level: 20}, opponents, tests[0].query); err != ErrQueryInputOutOfRange {
+		t.Errorf("got %v, want %v", err, ErrQueryInputOutOfRange)
+	}
+}
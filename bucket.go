@@ -0,0 +1,20 @@
+package gohbem
+
+// DefaultRankBuckets is example RankBuckets configuration, usable with Ohbem.RankBuckets.
+var DefaultRankBuckets = []RankBucket{
+	{Label: "rank 1", MaxRank: 1},
+	{Label: "top 10", MaxRank: 10},
+	{Label: "top 100", MaxRank: 100},
+	{Label: "top 1%", MaxPercentile: 0.01},
+	{Label: "top 10%", MaxPercentile: 0.1},
+}
+
+// rankBucket returns label of first RankBuckets entry matching provided rank or percentile, empty when none matches.
+func (o *Ohbem) rankBucket(rank int16, percentile float64) string {
+	for _, bucket := range o.RankBuckets {
+		if (bucket.MaxRank != 0 && rank <= bucket.MaxRank) || (bucket.MaxPercentile != 0 && percentile != 0 && percentile <= bucket.MaxPercentile) {
+			return bucket.Label
+		}
+	}
+	return ""
+}
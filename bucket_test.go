@@ -0,0 +1,65 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRankBucket(t *testing.T) {
+	ohbem := Ohbem{RankBuckets: DefaultRankBuckets}
+
+	var tests = []struct {
+		rank       int16
+		percentile float64
+		output     string
+	}{
+		{1, 0.00024, "rank 1"},
+		{1, 0, "rank 1"},
+		{7, 0.00171, "top 10"},
+		{40, 0.00977, "top 100"},
+		{120, 0.00977, "top 1%"},
+		{120, 0.04, "top 10%"},
+		{3000, 0.73, ""},
+		{3000, 0, ""},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			output := ohbem.rankBucket(test.rank, test.percentile)
+			if output != test.output {
+				t.Errorf("got %q, want %q", output, test.output)
+			}
+		})
+	}
+}
+
+func TestQueryPvPRankPercentile(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, RankBuckets: DefaultRankBuckets}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, 15, 15, 14, 1)
+	for league, leagueEntries := range entries {
+		for _, entry := range leagueEntries {
+			if league == "master" {
+				if entry.Bucket != "rank 1" || entry.Count != 0 {
+					t.Errorf("got %+v, want rank 1 bucket without count", entry)
+				}
+				continue
+			}
+			if entry.Count == 0 || entry.Count > 4096 || entry.Percentile != roundFloat(float64(entry.Rank)/float64(entry.Count), 5) {
+				t.Errorf("got %+v, want percentile of count", entry)
+			}
+			if entry.Bucket != ohbem.rankBucket(entry.Rank, entry.Percentile) {
+				t.Errorf("got %+v, wrong bucket", entry)
+			}
+		}
+	}
+	ultra := entries["ultra"][0]
+	if ultra.Rank != 21 || ultra.Bucket != "top 100" {
+		t.Errorf("got %+v, want rank 21 in top 100 bucket", ultra)
+	}
+}
@@ -0,0 +1,36 @@
+package gohbem
+
+import (
+	"testing"
+)
+
+func TestPruneCache(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	if _, err := ohbem.QueryPvPRank(661, 0, 0, 1, 0, 15, 15, 1); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	retained, evicted := ohbem.PruneCache()
+	if retained == 0 || evicted != 0 {
+		t.Errorf("got %d retained, %d evicted, want retained, 0 evicted", retained, evicted)
+	}
+	total := retained
+
+	delete(ohbem.PokemonData.Pokemon, 663)
+	retained, evicted = ohbem.PruneCache()
+	if evicted == 0 || retained == 0 || retained+evicted != total {
+		t.Errorf("got %d retained, %d evicted, want both of %d", retained, evicted, total)
+	}
+	if retained, evicted = ohbem.PruneCache(); evicted != 0 {
+		t.Errorf("got %d retained, %d evicted, want 0 evicted", retained, evicted)
+	}
+
+	disabled := Ohbem{Leagues: leagues, LevelCaps: levelCaps, DisableCache: true}
+	if retained, evicted := disabled.PruneCache(); retained != 0 || evicted != 0 {
+		t.Errorf("got %d retained, %d evicted, want 0, 0", retained, evicted)
+	}
+}
@@ -0,0 +1,192 @@
+package gohbem
+
+import (
+	"math"
+	"unsafe"
+)
+
+// RankingCriterion is one step of comparator built by NewRankingComparator.
+// Value extracts compared number from PvPRankingStats, higher values rank first unless Ascending is set.
+type RankingCriterion struct {
+	Value     func(s *PvPRankingStats) float64
+	Ascending bool
+}
+
+// Descending returns RankingCriterion preferring higher values.
+func Descending(value func(s *PvPRankingStats) float64) RankingCriterion {
+	return RankingCriterion{Value: value}
+}
+
+// Ascending returns RankingCriterion preferring lower values.
+func Ascending(value func(s *PvPRankingStats) float64) RankingCriterion {
+	return RankingCriterion{Value: value, Ascending: true}
+}
+
+// StatProduct returns stat product (attack * defense * HP).
+func StatProduct(s *PvPRankingStats) float64 {
+	return s.Value
+}
+
+// StatAttack returns effective attack.
+func StatAttack(s *PvPRankingStats) float64 {
+	return s.Attack
+}
+
+// StatDefense returns effective defense.
+func StatDefense(s *PvPRankingStats) float64 {
+	return s.Defense
+}
+
+// StatHp returns HP.
+func StatHp(s *PvPRankingStats) float64 {
+	return s.Hp
+}
+
+// StatBulk returns bulk (defense * HP).
+func StatBulk(s *PvPRankingStats) float64 {
+	return s.Defense * s.Hp
+}
+
+// StatCp returns CP.
+func StatCp(s *PvPRankingStats) float64 {
+	return float64(s.Cp)
+}
+
+// WeightedStats returns formula attack^attackWeight * defense^defenseWeight * HP^hpWeight.
+// Weights 1, 1, 1 are equal to stat product.
+func WeightedStats(attackWeight, defenseWeight, hpWeight float64) func(s *PvPRankingStats) float64 {
+	return func(s *PvPRankingStats) float64 {
+		return math.Pow(s.Attack, attackWeight) * math.Pow(s.Defense, defenseWeight) * math.Pow(s.Hp, hpWeight)
+	}
+}
+
+// AttackBreakpoint returns 1 when effective attack reaches provided threshold, 0 otherwise.
+func AttackBreakpoint(threshold float64) func(s *PvPRankingStats) float64 {
+	return func(s *PvPRankingStats) float64 {
+		if s.Attack >= threshold {
+			return 1
+		}
+		return 0
+	}
+}
+
+// NewRankingComparator builds RankingComparator comparing provided criteria in order, first difference wins.
+//
+// Example (prefer bulk, then stat product):
+//
+//	comparator := NewRankingComparator(Descending(StatBulk), Descending(StatProduct))
+//	err := ohbem.UseRankingComparator("bulk_first", comparator)
+func NewRankingComparator(criteria ...RankingCriterion) RankingComparator {
+	return func(a, b *PvPRankingStats) int {
+		for _, criterion := range criteria {
+			d := criterion.Value(b) - criterion.Value(a)
+			if criterion.Ascending {
+				d = -d
+			}
+			if d > 0 {
+				return 1
+			}
+			if d < 0 {
+				return -1
+			}
+		}
+		return 0
+	}
+}
+
+// RankingComparatorPreferBulk in addition to the default rules, also compare by bulk (defense * HP) descending in the end.
+// Between ties, bulkier one is more likely to survive a charged move.
+var RankingComparatorPreferBulk = NewRankingComparator(
+	Descending(StatProduct), Descending(StatAttack), Descending(StatBulk),
+)
+
+// RankingComparatorMaxBulk ranks everything by bulk (defense * HP) descending then by stat product descending.
+var RankingComparatorMaxBulk = NewRankingComparator(
+	Descending(StatBulk), Descending(StatProduct), Descending(StatAttack),
+)
+
+// RankingComparatorMaxAttack ranks everything by attack descending then by stat product descending.
+// Useful when winning CMP ties and reaching breakpoints matter more than overall stat product.
+var RankingComparatorMaxAttack = NewRankingComparator(
+	Descending(StatAttack), Descending(StatProduct),
+)
+
+// RankingComparatorAttackWeighted ranks by stat product with attack weighted 1.5 times.
+var RankingComparatorAttackWeighted = NewRankingComparator(
+	Descending(WeightedStats(1.5, 1, 1)), Descending(StatProduct),
+)
+
+// RankingComparatorBreakpoint ranks IV spreads reaching provided attack threshold first, then by default rules.
+func RankingComparatorBreakpoint(threshold float64) RankingComparator {
+	return NewRankingComparator(
+		Descending(AttackBreakpoint(threshold)), Descending(StatProduct), Descending(StatAttack),
+	)
+}
+
+// RankingComparators is holding named presets usable with UseRankingComparatorPreset.
+var RankingComparators = map[string]RankingComparator{
+	"default":          RankingComparatorDefault,
+	"prefer_higher_cp": RankingComparatorPreferHigherCp,
+	"prefer_lower_cp":  RankingComparatorPreferLowerCp,
+	"prefer_bulk":      RankingComparatorPreferBulk,
+	"max_bulk":         RankingComparatorMaxBulk,
+	"max_attack":       RankingComparatorMaxAttack,
+	"attack_weighted":  RankingComparatorAttackWeighted,
+}
+
+// UseRankingComparator sets RankingComparator together with key distinguishing its results in cache.
+// Comparators set under the same key share cache entries, so each comparator needs its own non-empty key.
+func (o *Ohbem) UseRankingComparator(key string, comparator RankingComparator) error {
+	if key == "" {
+		return ErrRankingComparatorKey
+	}
+	o.comparatorMutex.Lock()
+	defer o.comparatorMutex.Unlock()
+	o.RankingComparator = comparator
+	o.keyedComparator = comparator
+	o.keyedComparatorKey = key
+	return nil
+}
+
+// comparator returns RankingComparator, RankingComparatorDefault when none is set.
+func (o *Ohbem) comparator() RankingComparator {
+	if o.RankingComparator == nil {
+		return RankingComparatorDefault
+	}
+	return o.RankingComparator
+}
+
+// comparatorKey returns cache key of RankingComparator.
+// Comparators set directly, without UseRankingComparator, share empty key with the default one,
+// so cache is cleared whenever such comparator changes.
+func (o *Ohbem) comparatorKey() string {
+	o.comparatorMutex.Lock()
+	defer o.comparatorMutex.Unlock()
+	current := comparatorId(o.RankingComparator)
+	if current != 0 && current == comparatorId(o.keyedComparator) {
+		return o.keyedComparatorKey
+	}
+	if current != comparatorId(o.directComparator) {
+		o.compactRankCache.Range(func(key, _ any) bool {
+			o.compactRankCache.Delete(key)
+			return true
+		})
+		o.directComparator = o.RankingComparator
+	}
+	return ""
+}
+
+// comparatorId returns identity of comparator, 0 for nil.
+// Closures built by NewRankingComparator share code, so their closure pointers are compared instead.
+func comparatorId(comparator RankingComparator) uintptr {
+	return *(*uintptr)(unsafe.Pointer(&comparator))
+}
+
+// UseRankingComparatorPreset sets one of RankingComparators presets by its name.
+func (o *Ohbem) UseRankingComparatorPreset(name string) error {
+	comparator, ok := RankingComparators[name]
+	if !ok {
+		return ErrRankingComparatorUnknown
+	}
+	return o.UseRankingComparator(name, comparator)
+}
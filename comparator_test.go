@@ -0,0 +1,125 @@
+package gohbem
+
+import "testing"
+
+func TestNewRankingComparator(t *testing.T) {
+	expected, _, _ := calculateRanksCompact(&PikachuStats, 1500, 50, RankingComparatorDefault, 0)
+	built, _, _ := calculateRanksCompact(&PikachuStats, 1500, 50, NewRankingComparator(Descending(StatProduct), Descending(StatAttack)), 0)
+	if *expected != *built {
+		t.Errorf("built comparator differs from RankingComparatorDefault")
+	}
+
+	weighted, _, _ := calculateRanksCompact(&PikachuStats, 1500, 50, NewRankingComparator(Descending(WeightedStats(1, 1, 1)), Descending(StatAttack)), 0)
+	for ix := range expected {
+		if (expected[ix] == 1) != (weighted[ix] == 1) {
+			t.Errorf("weighted comparator rank 1 differs at %d", ix)
+		}
+	}
+}
+
+func TestRankingComparatorPresets(t *testing.T) {
+	var tests = []struct {
+		name  string
+		value func(s *PvPRankingStats) float64
+	}{
+		{"default", StatProduct},
+		{"max_bulk", StatBulk},
+		{"max_attack", StatAttack},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, sortedRanks, _ := calculateRanksCompact(&ElgyemStats, 1500, 50, RankingComparators[test.name], 0)
+			top := test.value(&sortedRanks[0])
+			for i := 1; i < 4096; i++ {
+				if test.value(&sortedRanks[i]) > top {
+					t.Errorf("got %f at %d, higher than top %f", test.value(&sortedRanks[i]), i, top)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRankingComparatorBreakpoint(t *testing.T) {
+	_, sortedRanks, _ := calculateRanksCompact(&ElgyemStats, 1500, 50, RankingComparatorBreakpoint(126), 0)
+	if sortedRanks[0].Attack < 126 {
+		t.Errorf("got attack %f, want at least 126", sortedRanks[0].Attack)
+	}
+}
+
+func TestUseRankingComparatorPreset(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	if err := ohbem.UseRankingComparatorPreset("missing"); err != ErrRankingComparatorUnknown {
+		t.Errorf("got %v, want %v", err, ErrRankingComparatorUnknown)
+	}
+
+	defaultRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	_ = ohbem.UseRankingComparatorPreset("max_bulk")
+	bulkRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	if *defaultRanks[50].Combinations == *bulkRanks[50].Combinations {
+		t.Errorf("cache returned default ranks for max_bulk comparator")
+	}
+	_ = ohbem.UseRankingComparatorPreset("default")
+	cachedRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	if *defaultRanks[50].Combinations != *cachedRanks[50].Combinations {
+		t.Errorf("got different ranks for default comparator")
+	}
+}
+
+func TestRankingComparatorPresetsPercentage(t *testing.T) {
+	for name := range RankingComparators {
+		t.Run(name, func(t *testing.T) {
+			ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+			err := ohbem.LoadPokemonData("./test/master-test.json")
+			if err != nil {
+				t.Errorf("can't load MasterFile")
+			}
+			_ = ohbem.UseRankingComparatorPreset(name)
+			for _, ivs := range [][3]int{{0, 15, 15}, {15, 0, 0}, {15, 15, 15}, {4, 14, 13}} {
+				entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, ivs[0], ivs[1], ivs[2], 1)
+				for _, leagueEntries := range entries {
+					for _, entry := range leagueEntries {
+						if entry.Percentage > 1 {
+							t.Errorf("got percentage %f, want at most 1 for %v %+v", entry.Percentage, ivs, entry)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRankingComparatorField(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.UseRankingComparator("", RankingComparatorMaxBulk); err != ErrRankingComparatorKey {
+		t.Errorf("got %v, want %v", err, ErrRankingComparatorKey)
+	}
+
+	defaultRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	ohbem.RankingComparator = RankingComparatorMaxBulk
+	bulkRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	if *defaultRanks[50].Combinations == *bulkRanks[50].Combinations {
+		t.Errorf("cache returned default ranks for directly set comparator")
+	}
+	ohbem.RankingComparator = NewRankingComparator(Descending(StatAttack), Descending(StatProduct))
+	attackRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	if *attackRanks[50].Combinations == *bulkRanks[50].Combinations {
+		t.Errorf("cache returned previous ranks for replaced comparator")
+	}
+	ohbem.RankingComparator = nil
+	cachedRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	if *defaultRanks[50].Combinations != *cachedRanks[50].Combinations {
+		t.Errorf("got different ranks for default comparator")
+	}
+
+	_ = ohbem.UseRankingComparator("bulk", RankingComparatorMaxBulk)
+	keyedRanks, _ := ohbem.calculateAllRanksCompact(&ElgyemStats, 1500, levelCaps, 0)
+	if *keyedRanks[50].Combinations != *bulkRanks[50].Combinations {
+		t.Errorf("got different ranks for keyed comparator")
+	}
+}
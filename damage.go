@@ -0,0 +1,81 @@
+package gohbem
+
+import "math"
+
+// pvpDamageBonus is a flat damage multiplier applied to all PvP moves.
+const pvpDamageBonus = 1.3
+
+// calculateDamage is used to calculate PvP damage of a move with provided power between effective attack and defense.
+func calculateDamage(power int, attack, defense, multiplier float64) int {
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	return int(math.Floor(0.5*float64(power)*attack/defense*multiplier*pvpDamageBonus)) + 1
+}
+
+// resolveTypes returns types of Pokemon form, falling back to Pokemon types.
+func (o *Ohbem) resolveTypes(pokemonId, form int) []int {
+	masterPokemon := o.PokemonData.Pokemon[pokemonId]
+	if masterForm, ok := masterPokemon.Forms[form]; ok && form != 0 && len(masterForm.Types) != 0 {
+		return masterForm.Types
+	}
+	return masterPokemon.Types
+}
+
+// FindMoves Look up fast and charged moves of a Pokémon form, falling back to Pokemon moves.
+func (o *Ohbem) FindMoves(pokemonId int, form int) ([]int, []int, error) {
+	if err := safetyCheck(o); err != nil {
+		return nil, nil, err
+	}
+
+	masterPokemon, ok := o.PokemonData.Pokemon[pokemonId]
+	if !ok {
+		return nil, nil, ErrMissingPokemon
+	}
+	fastMoves, chargedMoves := masterPokemon.FastMoves, masterPokemon.ChargedMoves
+	if masterForm, ok := masterPokemon.Forms[form]; ok && form != 0 {
+		if len(masterForm.FastMoves) != 0 {
+			fastMoves = masterForm.FastMoves
+		}
+		if len(masterForm.ChargedMoves) != 0 {
+			chargedMoves = masterForm.ChargedMoves
+		}
+	}
+	return fastMoves, chargedMoves, nil
+}
+
+// TypeEffectiveness Calculate type effectiveness multiplier of attacking type against defender Pokémon form.
+func (o *Ohbem) TypeEffectiveness(attackType int, pokemonId int, form int) float64 {
+	return calculateTypeEffectiveness(o.PokemonData.TypeEffectiveness, attackType, o.resolveTypes(pokemonId, form))
+}
+
+// CalculatePvPDamage Calculate PvP damage of move used by attacker against defender, including STAB and type effectiveness.
+func (o *Ohbem) CalculatePvPDamage(moveId int, attacker BattlePokemon, defender BattlePokemon) (int, error) {
+	if err := safetyCheck(o); err != nil {
+		return 0, err
+	}
+
+	move, ok := o.PokemonData.Moves[moveId]
+	if !ok {
+		return 0, ErrMissingMove
+	}
+	if attacker.Level < 1 || defender.Level < 1 {
+		return 0, ErrQueryInputOutOfRange
+	}
+	attackerStats, err := o.resolveStats(attacker.Pokemon, attacker.Form, attacker.Evolution)
+	if err != nil {
+		return 0, err
+	}
+	defenderStats, err := o.resolveStats(defender.Pokemon, defender.Form, defender.Evolution)
+	if err != nil {
+		return 0, err
+	}
+
+	multiplier := o.TypeEffectiveness(move.Type, defender.Pokemon, defender.Form)
+	if containsInt(o.resolveTypes(attacker.Pokemon, attacker.Form), move.Type) {
+		multiplier *= stabMultiplier
+	}
+	attack := float64(attackerStats.Attack+attacker.Attack) * calculateCpMultiplier(attacker.Level)
+	defense := float64(defenderStats.Defense+defender.Defense) * calculateCpMultiplier(defender.Level)
+	return calculateDamage(move.Power, attack, defense, multiplier), nil
+}
@@ -0,0 +1,114 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCalculateDamage(t *testing.T) {
+	var tests = []struct {
+		power      int
+		attack     float64
+		defense    float64
+		multiplier float64
+		output     int
+	}{
+		{3, 100, 100, 0, 2},
+		{3, 100, 100, 1.2, 3},
+		{90, 130, 110, 1.6, 111},
+		{10, 150, 90, 0.625, 7},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			output := calculateDamage(test.power, test.attack, test.defense, test.multiplier)
+			if output != test.output {
+				t.Errorf("got %d, want %d", output, test.output)
+			}
+		})
+	}
+}
+
+func TestCalculateTypeEffectiveness(t *testing.T) {
+	var tests = []struct {
+		attackType   int
+		defenseTypes []int
+		output       float64
+	}{
+		{TypeNormal, []int{TypeNormal}, 1},
+		{TypeFighting, []int{TypeNormal}, EffectivenessSuper},
+		{TypeFighting, []int{TypeWater, TypeFairy}, EffectivenessNotVery},
+		{TypeDragon, []int{TypeWater, TypeFairy}, EffectivenessImmune},
+		{TypeElectric, []int{TypeWater, TypeFlying}, 2.5600000000000005},
+		{TypeGround, []int{TypeFire, TypeFlying}, 0.625},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			output := calculateTypeEffectiveness(nil, test.attackType, test.defenseTypes)
+			if output != test.output {
+				t.Errorf("got %f, want %f", output, test.output)
+			}
+		})
+	}
+}
+
+func TestFindMoves(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-moves-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	fastMoves, chargedMoves, err := ohbem.FindMoves(184, 0)
+	if err != nil || len(fastMoves) != 1 || len(chargedMoves) != 3 {
+		t.Errorf("got %v %v %v, want 1 fast and 3 charged moves", fastMoves, chargedMoves, err)
+	}
+	if move := ohbem.PokemonData.Moves[fastMoves[0]]; move.Name != "Bubble" || move.Turns != 3 || move.Type != TypeWater {
+		t.Errorf("got %+v, want Bubble", move)
+	}
+	if _, _, err := ohbem.FindMoves(1, 0); err != ErrMissingPokemon {
+		t.Errorf("got %v, want %v", err, ErrMissingPokemon)
+	}
+}
+
+func TestCalculatePvPDamage(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-moves-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	azumarill := BattlePokemon{Pokemon: 184, Attack: 8, Defense: 15, Stamina: 15, Level: 40}
+	medicham := BattlePokemon{Pokemon: 308, Attack: 15, Defense: 15, Stamina: 15, Level: 49}
+	talonflame := BattlePokemon{Pokemon: 663, Attack: 0, Defense: 15, Stamina: 15, Level: 22}
+
+	var tests = []struct {
+		move     int
+		attacker BattlePokemon
+		defender BattlePokemon
+		output   int
+	}{
+		{224, medicham, azumarill, 4},    // resisted, STAB
+		{387, azumarill, medicham, 77},   // super effective, STAB
+		{237, azumarill, talonflame, 9},  // super effective, STAB
+		{224, medicham, talonflame, 5},   // resisted, STAB
+		{298, talonflame, medicham, 129}, // super effective, STAB
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			output, err := ohbem.CalculatePvPDamage(test.move, test.attacker, test.defender)
+			if err != nil || output != test.output {
+				t.Errorf("got %d %v, want %d", output, err, test.output)
+			}
+		})
+	}
+
+	if _, err := ohbem.CalculatePvPDamage(1, azumarill, medicham); err != ErrMissingMove {
+		t.Errorf("got %v, want %v", err, ErrMissingMove)
+	}
+}
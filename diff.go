@@ -0,0 +1,141 @@
+package gohbem
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// sortedKeys returns keys of map in ascending order.
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// DiffPokemonData Compare two versions of MasterFile, listing added and removed species and forms, base stat changes,
+// temp evolution changes (including release status) and evolution edits.
+func DiffPokemonData(old, new PokemonData) MasterFileDiff {
+	var diff MasterFileDiff
+
+	for _, pokemonId := range sortedKeys(old.Pokemon) {
+		if _, ok := new.Pokemon[pokemonId]; !ok {
+			diff.RemovedPokemon = append(diff.RemovedPokemon, pokemonId)
+		}
+	}
+	for _, pokemonId := range sortedKeys(new.Pokemon) {
+		newPokemon := new.Pokemon[pokemonId]
+		oldPokemon, ok := old.Pokemon[pokemonId]
+		if !ok {
+			diff.AddedPokemon = append(diff.AddedPokemon, pokemonId)
+			continue
+		}
+
+		key := PokemonForm{Pokemon: pokemonId}
+		diff.diffStats(key, PokemonStats{Attack: oldPokemon.Attack, Defense: oldPokemon.Defense, Stamina: oldPokemon.Stamina},
+			PokemonStats{Attack: newPokemon.Attack, Defense: newPokemon.Defense, Stamina: newPokemon.Stamina})
+		diff.diffTempEvolutions(key, oldPokemon.TempEvolutions, newPokemon.TempEvolutions)
+		diff.diffEvolutions(key, oldPokemon.Evolutions, newPokemon.Evolutions)
+
+		for _, formId := range sortedKeys(oldPokemon.Forms) {
+			if _, ok := newPokemon.Forms[formId]; !ok {
+				diff.RemovedForms = append(diff.RemovedForms, PokemonForm{pokemonId, formId})
+			}
+		}
+		for _, formId := range sortedKeys(newPokemon.Forms) {
+			key := PokemonForm{pokemonId, formId}
+			newForm := newPokemon.Forms[formId]
+			oldForm, ok := oldPokemon.Forms[formId]
+			if !ok {
+				diff.AddedForms = append(diff.AddedForms, key)
+				continue
+			}
+			if formId == 0 {
+				continue
+			}
+			diff.diffStats(key, PokemonStats{Attack: oldForm.Attack, Defense: oldForm.Defense, Stamina: oldForm.Stamina},
+				PokemonStats{Attack: newForm.Attack, Defense: newForm.Defense, Stamina: newForm.Stamina})
+			diff.diffTempEvolutions(key, oldForm.TempEvolutions, newForm.TempEvolutions)
+			diff.diffEvolutions(key, oldForm.Evolutions, newForm.Evolutions)
+		}
+	}
+	return diff
+}
+
+// equalStats reports whether both stats, including release status and types, are the same.
+func equalStats(a, b PokemonStats) bool {
+	return a.Attack == b.Attack && a.Defense == b.Defense && a.Stamina == b.Stamina && a.Unreleased == b.Unreleased && equalInts(a.Types, b.Types)
+}
+
+func (d *MasterFileDiff) diffStats(key PokemonForm, old, new PokemonStats) {
+	if !equalStats(old, new) {
+		d.StatChanges = append(d.StatChanges, StatChange{PokemonForm: key, Old: old, New: new})
+	}
+}
+
+func (d *MasterFileDiff) diffTempEvolutions(key PokemonForm, old, new map[int]PokemonStats) {
+	for _, evolution := range sortedKeys(old) {
+		if _, ok := new[evolution]; !ok {
+			oldStats := old[evolution]
+			d.TempEvolutionChanges = append(d.TempEvolutionChanges, TempEvolutionChange{PokemonForm: key, Evolution: evolution, Old: &oldStats})
+		}
+	}
+	for _, evolution := range sortedKeys(new) {
+		newStats := new[evolution]
+		oldStats, ok := old[evolution]
+		change := TempEvolutionChange{PokemonForm: key, Evolution: evolution, New: &newStats}
+		if !ok {
+			d.TempEvolutionChanges = append(d.TempEvolutionChanges, change)
+			continue
+		}
+		if equalStats(oldStats, newStats) {
+			continue
+		}
+		change.Old = &oldStats
+		d.TempEvolutionChanges = append(d.TempEvolutionChanges, change)
+		if oldStats.Unreleased != newStats.Unreleased {
+			d.ReleaseChanges = append(d.ReleaseChanges, change)
+		}
+	}
+}
+
+func (d *MasterFileDiff) diffEvolutions(key PokemonForm, old, new []Evolution) {
+	if len(old) == 0 && len(new) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(old, new) {
+		d.EvolutionChanges = append(d.EvolutionChanges, EvolutionChange{PokemonForm: key, Old: old, New: new})
+	}
+}
+
+// IsEmpty returns true when MasterFileDiff has no changes.
+func (d MasterFileDiff) IsEmpty() bool {
+	return len(d.AddedPokemon) == 0 && len(d.RemovedPokemon) == 0 && len(d.AddedForms) == 0 && len(d.RemovedForms) == 0 &&
+		len(d.StatChanges) == 0 && len(d.TempEvolutionChanges) == 0 && len(d.EvolutionChanges) == 0
+}
+
+// String returns one line summary of MasterFileDiff used in logs.
+func (d MasterFileDiff) String() string {
+	var parts []string
+	count := func(n int, label string) {
+		if n != 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, label))
+		}
+	}
+	count(len(d.AddedPokemon), "added Pokemon")
+	count(len(d.RemovedPokemon), "removed Pokemon")
+	count(len(d.AddedForms), "added forms")
+	count(len(d.RemovedForms), "removed forms")
+	count(len(d.StatChanges), "stat changes")
+	count(len(d.TempEvolutionChanges), "temp evolution changes")
+	count(len(d.ReleaseChanges), "release changes")
+	count(len(d.EvolutionChanges), "evolution changes")
+	if len(parts) == 0 {
+		return "no Pokemon changes"
+	}
+	return strings.Join(parts, ", ")
+}
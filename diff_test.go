@@ -0,0 +1,93 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func loadTestPokemonData(t *testing.T) PokemonData {
+	var data PokemonData
+	raw, err := os.ReadFile("./test/master-test.json")
+	if err != nil {
+		t.Fatalf("can't load MasterFile")
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("can't unmarshal MasterFile")
+	}
+	return data
+}
+
+func TestDiffPokemonData(t *testing.T) {
+	old := loadTestPokemonData(t)
+	if diff := DiffPokemonData(old, loadTestPokemonData(t)); !diff.IsEmpty() {
+		t.Errorf("got %s, want empty diff", diff)
+	}
+
+	new := loadTestPokemonData(t)
+	delete(new.Pokemon, 1)
+	new.Pokemon[100000] = Pokemon{Attack: 1, Defense: 1, Stamina: 1}
+
+	talonflame := new.Pokemon[663]
+	talonflame.Attack++
+	new.Pokemon[663] = talonflame
+
+	venusaur := new.Pokemon[3]
+	venusaur.TempEvolutions[1] = PokemonStats{Attack: 242, Defense: 246, Stamina: 190}
+	venusaur.TempEvolutions[2] = PokemonStats{Attack: 1, Defense: 1, Stamina: 1}
+	new.Pokemon[3] = venusaur
+
+	mewtwo := new.Pokemon[150]
+	for evolution, stats := range mewtwo.TempEvolutions {
+		if stats.Unreleased {
+			stats.Unreleased = false
+			mewtwo.TempEvolutions[evolution] = stats
+		}
+	}
+
+	fletchling := new.Pokemon[661]
+	fletchling.Evolutions = []Evolution{{Pokemon: 663}}
+	fletchling.Forms[1] = Form{}
+	delete(fletchling.Forms, 0)
+	new.Pokemon[661] = fletchling
+
+	diff := DiffPokemonData(old, new)
+
+	var tests = []struct {
+		got      interface{}
+		expected interface{}
+	}{
+		{diff.AddedPokemon, []int{100000}},
+		{diff.RemovedPokemon, []int{1}},
+		{diff.AddedForms, []PokemonForm{{661, 1}}},
+		{diff.RemovedForms, []PokemonForm{{661, 0}}},
+		{len(diff.StatChanges), 1},
+		{diff.StatChanges[0].PokemonForm, PokemonForm{Pokemon: 663}},
+		{diff.StatChanges[0].New.Attack - diff.StatChanges[0].Old.Attack, 1},
+		{len(diff.TempEvolutionChanges), 2 + len(diff.ReleaseChanges)},
+		{len(diff.ReleaseChanges) > 0, true},
+		{diff.ReleaseChanges[0].Pokemon, 150},
+		{len(diff.EvolutionChanges), 1},
+		{diff.EvolutionChanges[0].New, []Evolution{{Pokemon: 663}}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if !reflect.DeepEqual(test.got, test.expected) {
+				t.Errorf("got %v, want %v", test.got, test.expected)
+			}
+		})
+	}
+
+	for _, change := range diff.TempEvolutionChanges {
+		if change.Pokemon == 3 && change.Evolution == 2 && (change.Old != nil || change.New == nil) {
+			t.Errorf("got %+v, want added temp evolution", change)
+		}
+	}
+	if diff.IsEmpty() || diff.String() == "no Pokemon changes" {
+		t.Errorf("got %s, want changes", diff)
+	}
+}
@@ -0,0 +1,37 @@
+package gohbem
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"encoding/json"
+	"io"
+)
+
+//go:generate go run ./internal/genmasterfile -out masterfile.json.gz
+
+// embeddedMasterFile is gzip compressed MasterFile snapshot, with its fetch time stored in gzip header.
+// Snapshot fetched from MasterFileURL is written by go generate; until then it holds test/master-test.json.
+//
+//go:embed masterfile.json.gz
+var embeddedMasterFile []byte
+
+// LoadEmbeddedPokemonData Load MasterFile snapshot embedded in gohbem and keep it in memory, without any file or network I/O.
+// Snapshot is refreshed by go generate; MasterFileStatus reports when it was fetched.
+func (o *Ohbem) LoadEmbeddedPokemonData() error {
+	reader, err := gzip.NewReader(bytes.NewReader(embeddedMasterFile))
+	if err != nil {
+		return ErrMasterFileUnmarshall
+	}
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return ErrMasterFileUnmarshall
+	}
+	var pokemonData PokemonData
+	if err := json.Unmarshal(raw, &pokemonData); err != nil {
+		return ErrMasterFileUnmarshall
+	}
+	pokemonData.raw = raw
+	o.usePokemonData(pokemonData, MasterFileSourceEmbedded, reader.ModTime)
+	return nil
+}
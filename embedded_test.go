@@ -0,0 +1,48 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLoadEmbeddedPokemonData(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadEmbeddedPokemonData(); err != nil {
+		t.Fatalf("LoadEmbeddedPokemonData returned error: %s", err)
+	}
+	if !ohbem.PokemonData.Initialized || len(ohbem.PokemonData.Pokemon) == 0 {
+		t.Errorf("embedded MasterFile is empty")
+	}
+
+	status := ohbem.MasterFileStatus()
+	if status.Source != MasterFileSourceEmbedded || status.UpdatedAt.IsZero() {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	var tests = []struct {
+		pokemonId int
+		attack    int
+		defense   int
+		stamina   int
+	}{
+		{1, 15, 15, 15},
+		{25, 0, 15, 15},
+		{663, 15, 15, 14},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if masterPokemon, ok := ohbem.PokemonData.Pokemon[test.pokemonId]; !ok || masterPokemon.Attack == 0 {
+				t.Fatalf("got no stats for %d in embedded MasterFile", test.pokemonId)
+			}
+			entries, err := ohbem.QueryPvPRank(test.pokemonId, 0, 0, 1, test.attack, test.defense, test.stamina, 1)
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if len(entries["great"]) == 0 && len(entries["ultra"]) == 0 && len(entries["little"]) == 0 {
+				t.Errorf("got no entries %+v", entries)
+			}
+		})
+	}
+}
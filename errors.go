@@ -43,3 +43,54 @@ var ErrLeaguesMissing = errors.New("leagues configuration is empty")
 
 // ErrLevelCapsMissing is returned when levelCaps configuration is empty.
 var ErrLevelCapsMissing = errors.New("levelCaps configuration is empty")
+
+// ErrRankingComparatorUnknown is returned when RankingComparator preset is missing in RankingComparators.
+var ErrRankingComparatorUnknown = errors.New("unknown ranking comparator preset")
+
+// ErrRankingComparatorKey is returned when RankingComparator is set with empty cache key.
+var ErrRankingComparatorKey = errors.New("empty ranking comparator key")
+
+// ErrLeagueMissing is returned when League is missing in Leagues configuration.
+var ErrLeagueMissing = errors.New("missing league in leagues configuration")
+
+// ErrMissingMove is returned when Move is missing in MasterFile.
+var ErrMissingMove = errors.New("missing moveID in MasterFile")
+
+// ErrBattleMoveInvalid is returned when Combatant fast move is charged one or vice versa.
+var ErrBattleMoveInvalid = errors.New("invalid fast or charged move for battle")
+
+// ErrEvolutionRulesOpen is returned when EvolutionRules file can't be open.
+var ErrEvolutionRulesOpen = errors.New("can't open EvolutionRules")
+
+// ErrEvolutionRulesUnmarshall is returned when UnMarshal of EvolutionRules fail.
+var ErrEvolutionRulesUnmarshall = errors.New("can't unmarshal EvolutionRules")
+
+// ErrMasterFileHistoryDisabled is returned when MasterFileHistoryPath is not set.
+var ErrMasterFileHistoryDisabled = errors.New("MasterFile history is disabled")
+
+// ErrMasterFileVersionMissing is returned when MasterFile snapshot with provided fingerprint doesn't exist.
+var ErrMasterFileVersionMissing = errors.New("missing MasterFile version")
+
+// ErrGameMasterOpen is returned when game master file can't be open.
+var ErrGameMasterOpen = errors.New("can't open game master")
+
+// ErrGameMasterUnmarshall is returned when UnMarshal of game master fail.
+var ErrGameMasterUnmarshall = errors.New("can't unmarshal game master")
+
+// ErrImportOpen is returned when pvpoke or pogoapi file can't be open.
+var ErrImportOpen = errors.New("can't open imported file")
+
+// ErrImportUnmarshall is returned when UnMarshal of pvpoke or pogoapi file fail.
+var ErrImportUnmarshall = errors.New("can't unmarshal imported file")
+
+// ErrNamesOpen is returned when names file can't be open.
+var ErrNamesOpen = errors.New("can't open names")
+
+// ErrNamesUnmarshall is returned when UnMarshal of names file fail.
+var ErrNamesUnmarshall = errors.New("can't unmarshal names")
+
+// ErrNameNotFound is returned when name can't be resolved to ID.
+var ErrNameNotFound = errors.New("name not found")
+
+// ErrInventoryShadowForm is returned when shadow OwnedPokemon doesn't carry known shadow form.
+var ErrInventoryShadowForm = errors.New("missing shadow form")
@@ -0,0 +1,270 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Evolution condition kinds.
+const (
+	EvolutionConditionHighestStat = "highest_stat" // Stat IV must be at least as high as both others
+	EvolutionConditionGender      = "gender"       // Gender must match
+	EvolutionConditionRandom      = "random"       // one of random outcomes (Wurmple-style)
+	EvolutionConditionItem        = "item"         // Item is needed to evolve
+	EvolutionConditionTimeOfDay   = "time_of_day"  // evolves only during TimeOfDay
+)
+
+// Stats used by EvolutionConditionHighestStat.
+const (
+	EvolutionStatAttack  = "attack"
+	EvolutionStatDefense = "defense"
+	EvolutionStatStamina = "stamina"
+)
+
+// DefaultEvolutionRules is holding built-in rules, always applied before Ohbem.EvolutionRules.
+// They are also applied on query to evolutions without Conditions, so PokemonData assigned directly behaves like loaded one.
+var DefaultEvolutionRules = []EvolutionRule{
+	{Pokemon: 236, Target: 106, Conditions: []EvolutionCondition{{Kind: EvolutionConditionHighestStat, Stat: EvolutionStatAttack}}},
+	{Pokemon: 236, Target: 107, Conditions: []EvolutionCondition{{Kind: EvolutionConditionHighestStat, Stat: EvolutionStatDefense}}},
+	{Pokemon: 236, Target: 237, Conditions: []EvolutionCondition{{Kind: EvolutionConditionHighestStat, Stat: EvolutionStatStamina}}},
+}
+
+// LoadEvolutionRules Load supplementary EvolutionRules from provided filePath and apply them on loaded MasterFile.
+func (o *Ohbem) LoadEvolutionRules(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return ErrEvolutionRulesOpen
+	}
+	var rules []EvolutionRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return ErrEvolutionRulesUnmarshall
+	}
+	o.EvolutionRules = rules
+	if o.PokemonData.Initialized {
+		o.pokemonDataMutex.Lock()
+		applyEvolutionRules(&o.PokemonData, o.evolutionRules())
+		o.pokemonDataMutex.Unlock()
+	}
+	return nil
+}
+
+// preparePokemonData applies configured EvolutionRules on freshly loaded MasterFile.
+func (o *Ohbem) preparePokemonData(data *PokemonData) {
+	applyEvolutionRules(data, o.evolutionRules())
+	buildPreEvolutions(data)
+}
+
+// evolutionRuleKey identifies evolution affected by EvolutionRule.
+type evolutionRuleKey struct {
+	Pokemon    int
+	Form       int
+	Target     int
+	TargetForm int
+}
+
+// evolutionRules returns DefaultEvolutionRules merged with Ohbem.EvolutionRules, configured rule replaces default one of the same evolution.
+func (o *Ohbem) evolutionRules() []EvolutionRule {
+	rules := append([]EvolutionRule(nil), DefaultEvolutionRules...)
+	index := make(map[evolutionRuleKey]int, len(rules))
+	for ix, rule := range rules {
+		index[evolutionRuleKey{rule.Pokemon, rule.Form, rule.Target, rule.TargetForm}] = ix
+	}
+	for _, rule := range o.EvolutionRules {
+		key := evolutionRuleKey{rule.Pokemon, rule.Form, rule.Target, rule.TargetForm}
+		if ix, ok := index[key]; ok {
+			rules[ix] = rule
+			continue
+		}
+		index[key] = len(rules)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// applyEvolutionRules replaces Conditions of evolutions matching provided rules.
+func applyEvolutionRules(data *PokemonData, rules []EvolutionRule) {
+	for _, rule := range rules {
+		masterPokemon, ok := data.Pokemon[rule.Pokemon]
+		if !ok {
+			continue
+		}
+		masterPokemon.Evolutions = applyEvolutionRule(masterPokemon.Evolutions, &rule)
+		for formId, masterForm := range masterPokemon.Forms {
+			if rule.Form == 0 || rule.Form == formId {
+				masterForm.Evolutions = applyEvolutionRule(masterForm.Evolutions, &rule)
+				masterPokemon.Forms[formId] = masterForm
+			}
+		}
+		data.Pokemon[rule.Pokemon] = masterPokemon
+	}
+}
+
+// matches returns true when rule applies to evolution of Pokemon form.
+func (rule *EvolutionRule) matches(pokemonId, form int, evolution *Evolution) bool {
+	return rule.Pokemon == pokemonId && (rule.Form == 0 || rule.Form == form) &&
+		rule.Target == evolution.Pokemon && (rule.TargetForm == 0 || rule.TargetForm == evolution.Form)
+}
+
+// defaultEvolutionConditions returns Conditions of DefaultEvolutionRules matching evolution, unless Ohbem.EvolutionRules replace them.
+func (o *Ohbem) defaultEvolutionConditions(pokemonId, form int, evolution *Evolution) []EvolutionCondition {
+	for ix := range DefaultEvolutionRules {
+		rule := &DefaultEvolutionRules[ix]
+		if !rule.matches(pokemonId, form, evolution) {
+			continue
+		}
+		for jx := range o.EvolutionRules {
+			configured := &o.EvolutionRules[jx]
+			if configured.Pokemon == rule.Pokemon && configured.Form == rule.Form && configured.Target == rule.Target && configured.TargetForm == rule.TargetForm {
+				return nil
+			}
+		}
+		return rule.Conditions
+	}
+	return nil
+}
+
+func applyEvolutionRule(evolutions []Evolution, rule *EvolutionRule) []Evolution {
+	var result []Evolution
+	for ix, evolution := range evolutions {
+		if evolution.Pokemon != rule.Target || (rule.TargetForm != 0 && evolution.Form != rule.TargetForm) {
+			continue
+		}
+		if result == nil {
+			result = append([]Evolution(nil), evolutions...)
+		}
+		result[ix].Conditions = rule.Conditions
+	}
+	if result == nil {
+		return evolutions
+	}
+	return result
+}
+
+// evaluateEvolutionConditions checks Evolution requirements against gender and IVs.
+// Conditions which can't be decided (random, item, time of day) keep evolution reachable with their own reason.
+func evaluateEvolutionConditions(evolution *Evolution, gender, attack, defense, stamina int) (bool, DecisionReason) {
+	if evolution.GenderRequirement != 0 && gender != evolution.GenderRequirement {
+		return false, DecisionGenderRequirement
+	}
+	reason := DecisionEvolution
+	for _, condition := range evolution.Conditions {
+		switch condition.Kind {
+		case EvolutionConditionHighestStat:
+			var highest bool
+			switch condition.Stat {
+			case EvolutionStatAttack:
+				highest = attack >= defense && attack >= stamina
+			case EvolutionStatDefense:
+				highest = defense >= attack && defense >= stamina
+			case EvolutionStatStamina:
+				highest = stamina >= attack && stamina >= defense
+			}
+			if !highest {
+				return false, DecisionStatRequirement
+			}
+		case EvolutionConditionGender:
+			if gender != condition.Gender {
+				return false, DecisionGenderRequirement
+			}
+		case EvolutionConditionRandom:
+			reason = DecisionRandomEvolution
+		case EvolutionConditionItem:
+			reason = DecisionItemRequirement
+		case EvolutionConditionTimeOfDay:
+			reason = DecisionTimeRequirement
+		}
+	}
+	return true, reason
+}
+
+// resolveForm returns Form of Pokemon, or Form built from Pokemon itself when form is 0 or missing.
+// Second value reports whether requested form was found.
+func resolveForm(masterPokemon *Pokemon, form int) (Form, bool) {
+	if masterForm, ok := masterPokemon.Forms[form]; ok && form != 0 {
+		return masterForm, true
+	}
+	return Form{
+		Attack:                    masterPokemon.Attack,
+		Defense:                   masterPokemon.Defense,
+		Stamina:                   masterPokemon.Stamina,
+		Little:                    masterPokemon.Little,
+		Types:                     masterPokemon.Types,
+		Evolutions:                masterPokemon.Evolutions,
+		TempEvolutions:            masterPokemon.TempEvolutions,
+		CostumeOverrideEvolutions: masterPokemon.CostumeOverrideEvolutions,
+		FormChanges:               masterPokemon.FormChanges,
+	}, false
+}
+
+// evolutionEdges evaluates direct evolutions of Pokemon form against costume, gender and IV requirements.
+func (o *Ohbem) evolutionEdges(pokemonId, form int, masterForm *Form, costume, gender, attack, defense, stamina int) []EvolutionEdge {
+	if len(masterForm.Evolutions) == 0 {
+		return nil
+	}
+	canEvolve := true
+	if costume != 0 {
+		canEvolve = !o.PokemonData.Costumes[costume] || containsInt(masterForm.CostumeOverrideEvolutions, costume)
+	}
+
+	edges := make([]EvolutionEdge, 0, len(masterForm.Evolutions))
+	for ix := range masterForm.Evolutions {
+		evolution := &masterForm.Evolutions[ix]
+		if len(evolution.Conditions) == 0 {
+			if conditions := o.defaultEvolutionConditions(pokemonId, form, evolution); conditions != nil {
+				withDefaults := *evolution
+				withDefaults.Conditions = conditions
+				evolution = &withDefaults
+			}
+		}
+		edge := EvolutionEdge{Pokemon: pokemonId, Form: form, Target: *evolution, Reachable: false, Reason: DecisionCostumeBlocksEvolution}
+		if canEvolve {
+			edge.Reachable, edge.Reason = evaluateEvolutionConditions(evolution, gender, attack, defense, stamina)
+		}
+		edges = append(edges, edge)
+	}
+	return edges
+}
+
+// EvolutionTargets Evaluate evolution graph reachable from a specific Pokémon, honoring costumes, gender and IV requirements.
+// Returned edges include unreachable ones together with the reason, reachable edges are followed recursively.
+func (o *Ohbem) EvolutionTargets(pokemonId int, form int, costume int, gender int, attack int, defense int, stamina int) ([]EvolutionEdge, error) {
+	var result []EvolutionEdge
+
+	if err := safetyCheck(o); err != nil {
+		return result, err
+	}
+
+	if (attack < 0 || attack > 15) || (defense < 0 || defense > 15) || (stamina < 0 || stamina > 15) {
+		return result, ErrQueryInputOutOfRange
+	}
+
+	if _, ok := o.PokemonData.Pokemon[pokemonId]; !ok {
+		return result, ErrMissingPokemon
+	}
+
+	visited := make(map[[2]int]bool)
+	var walk func(pokemonId, form int)
+	walk = func(pokemonId, form int) {
+		masterPokemon, ok := o.PokemonData.Pokemon[pokemonId]
+		if !ok {
+			return
+		}
+		masterForm, ok := resolveForm(&masterPokemon, form)
+		if !ok {
+			form = 0
+		}
+		key := [2]int{pokemonId, form}
+		if visited[key] {
+			return
+		}
+		visited[key] = true
+		for _, edge := range o.evolutionEdges(pokemonId, form, &masterForm, costume, gender, attack, defense, stamina) {
+			result = append(result, edge)
+			if edge.Reachable {
+				walk(edge.Target.Pokemon, edge.Target.Form)
+			}
+		}
+	}
+	walk(pokemonId, form)
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestEvolutionTargets(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var tests = []struct {
+		pokemonId int
+		form      int
+		costume   int
+		gender    int
+		a         int
+		d         int
+		s         int
+		reachable []int
+		blocked   map[int]DecisionReason
+	}{
+		{661, 0, 0, 1, 15, 15, 14, []int{662, 663}, nil},
+		{4, 0, 11, 1, 10, 10, 10, []int{5, 6}, nil},
+		{4, 0, 12, 1, 10, 10, 10, nil, map[int]DecisionReason{5: DecisionCostumeBlocksEvolution}},
+		{236, 0, 0, 1, 15, 10, 10, []int{106}, map[int]DecisionReason{107: DecisionStatRequirement, 237: DecisionStatRequirement}},
+		{236, 0, 0, 1, 10, 10, 15, []int{237}, map[int]DecisionReason{106: DecisionStatRequirement, 107: DecisionStatRequirement}},
+		{361, 0, 0, 1, 10, 10, 10, []int{362}, map[int]DecisionReason{478: DecisionGenderRequirement}},
+		{361, 0, 0, 2, 10, 10, 10, []int{362, 478}, nil},
+		{663, 0, 0, 1, 10, 10, 10, nil, nil},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			edges, err := ohbem.EvolutionTargets(test.pokemonId, test.form, test.costume, test.gender, test.a, test.d, test.s)
+			if err != nil {
+				t.Fatalf("got %v", err)
+			}
+			var reachable []int
+			blocked := make(map[int]DecisionReason)
+			for _, edge := range edges {
+				if edge.Reachable {
+					reachable = append(reachable, edge.Target.Pokemon)
+				} else {
+					blocked[edge.Target.Pokemon] = edge.Reason
+				}
+			}
+			if fmt.Sprint(reachable) != fmt.Sprint(test.reachable) || len(blocked) != len(test.blocked) {
+				t.Errorf("got %v %v, want %v %v", reachable, blocked, test.reachable, test.blocked)
+			}
+			for pokemonId, reason := range test.blocked {
+				if blocked[pokemonId] != reason {
+					t.Errorf("got %s for %d, want %s", blocked[pokemonId], pokemonId, reason)
+				}
+			}
+		})
+	}
+
+	if _, err := ohbem.EvolutionTargets(99999, 0, 0, 0, 0, 0, 0); err != ErrMissingPokemon {
+		t.Errorf("got %v, want %v", err, ErrMissingPokemon)
+	}
+}
+
+func TestEvaluateEvolutionConditions(t *testing.T) {
+	var tests = []struct {
+		evolution Evolution
+		gender    int
+		a         int
+		d         int
+		s         int
+		reachable bool
+		reason    DecisionReason
+	}{
+		{Evolution{Pokemon: 1}, 1, 0, 0, 0, true, DecisionEvolution},
+		{Evolution{Pokemon: 1, GenderRequirement: 2}, 1, 0, 0, 0, false, DecisionGenderRequirement},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionGender, Gender: 1}}}, 1, 0, 0, 0, true, DecisionEvolution},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionGender, Gender: 2}}}, 1, 0, 0, 0, false, DecisionGenderRequirement},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionHighestStat, Stat: EvolutionStatAttack}}}, 1, 15, 15, 14, true, DecisionEvolution},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionHighestStat, Stat: EvolutionStatDefense}}}, 1, 15, 14, 14, false, DecisionStatRequirement},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionHighestStat, Stat: EvolutionStatStamina}}}, 1, 1, 2, 3, true, DecisionEvolution},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionRandom}}}, 1, 0, 0, 0, true, DecisionRandomEvolution},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionItem, Item: 1}}}, 1, 0, 0, 0, true, DecisionItemRequirement},
+		{Evolution{Pokemon: 1, Conditions: []EvolutionCondition{{Kind: EvolutionConditionTimeOfDay, TimeOfDay: "day"}}}, 1, 0, 0, 0, true, DecisionTimeRequirement},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			reachable, reason := evaluateEvolutionConditions(&test.evolution, test.gender, test.a, test.d, test.s)
+			if reachable != test.reachable || reason != test.reason {
+				t.Errorf("got %t %s, want %t %s", reachable, reason, test.reachable, test.reason)
+			}
+		})
+	}
+}
+
+func TestLoadEvolutionRules(t *testing.T) {
+	unconditioned := []EvolutionRule{{Pokemon: 236, Target: 106}, {Pokemon: 236, Target: 107}, {Pokemon: 236, Target: 237}}
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, EvolutionRules: unconditioned}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	// configured rules without conditions replace defaults, Tyrogue can evolve into every Hitmon
+	edges, _ := ohbem.EvolutionTargets(236, 0, 0, 1, 15, 10, 10)
+	for _, edge := range edges {
+		if !edge.Reachable {
+			t.Errorf("got %+v, want reachable without conditions", edge)
+		}
+	}
+
+	if err := ohbem.LoadEvolutionRules("./test/missing.json"); err != ErrEvolutionRulesOpen {
+		t.Errorf("got %v, want %v", err, ErrEvolutionRulesOpen)
+	}
+	if err := ohbem.LoadEvolutionRules("./test/evolution-rules-test.json"); err != nil {
+		t.Fatalf("can't load EvolutionRules: %v", err)
+	}
+
+	var tests = []struct {
+		pokemonId int
+		form      int
+		gender    int
+		reasons   map[int]DecisionReason
+	}{
+		{236, 0, 1, map[int]DecisionReason{106: DecisionEvolution, 107: DecisionStatRequirement, 237: DecisionStatRequirement}},
+		{265, 0, 1, map[int]DecisionReason{266: DecisionRandomEvolution, 268: DecisionRandomEvolution}},
+		{133, 0, 1, map[int]DecisionReason{196: DecisionTimeRequirement, 197: DecisionTimeRequirement, 470: DecisionItemRequirement, 700: DecisionGenderRequirement, 134: DecisionEvolution}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			edges, _ := ohbem.EvolutionTargets(test.pokemonId, test.form, 0, test.gender, 15, 10, 10)
+			found := 0
+			for _, edge := range edges {
+				if reason, ok := test.reasons[edge.Target.Pokemon]; ok && edge.Pokemon == test.pokemonId {
+					found++
+					if edge.Reason != reason {
+						t.Errorf("got %s for %d, want %s", edge.Reason, edge.Target.Pokemon, reason)
+					}
+				}
+			}
+			if found != len(test.reasons) {
+				t.Errorf("got %d edges, want %d", found, len(test.reasons))
+			}
+		})
+	}
+}
+
+func TestLoadEvolutionRulesKeepsDefaults(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadEvolutionRules("./test/evolution-rules-wurmple-test.json"); err != nil {
+		t.Fatalf("can't load EvolutionRules: %v", err)
+	}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	if err := ohbem.LoadEvolutionRules("./test/evolution-rules-wurmple-test.json"); err != nil {
+		t.Fatalf("can't load EvolutionRules: %v", err)
+	}
+
+	reasons := map[int]DecisionReason{106: DecisionEvolution, 107: DecisionStatRequirement, 237: DecisionStatRequirement}
+	edges, _ := ohbem.EvolutionTargets(236, 0, 0, 1, 15, 10, 10)
+	for _, edge := range edges {
+		if reason, ok := reasons[edge.Target.Pokemon]; ok && edge.Pokemon == 236 && edge.Reason != reason {
+			t.Errorf("got %s for %d, want %s", edge.Reason, edge.Target.Pokemon, reason)
+		}
+	}
+	edges, _ = ohbem.EvolutionTargets(265, 0, 0, 1, 15, 10, 10)
+	for _, edge := range edges {
+		if edge.Pokemon == 265 && edge.Reason != DecisionRandomEvolution {
+			t.Errorf("got %s for %d, want %s", edge.Reason, edge.Target.Pokemon, DecisionRandomEvolution)
+		}
+	}
+}
+
+func TestEvolutionRulesOnAssignedPokemonData(t *testing.T) {
+	raw, err := os.ReadFile("./test/master-test.json")
+	if err != nil {
+		t.Fatalf("can't read MasterFile")
+	}
+	var pokemonData PokemonData
+	if err := json.Unmarshal(raw, &pokemonData); err != nil {
+		t.Fatalf("can't unmarshal MasterFile")
+	}
+	pokemonData.Initialized = true
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, PokemonData: pokemonData}
+
+	entries, err := ohbem.QueryPvPRank(236, 0, 0, 1, 15, 0, 0, 1)
+	if err != nil {
+		t.Fatalf("got %v", err)
+	}
+	for league, leagueEntries := range entries {
+		for _, entry := range leagueEntries {
+			if entry.Pokemon == 107 || entry.Pokemon == 237 {
+				t.Errorf("got %d in %s, want only Tyrogue and Hitmonlee", entry.Pokemon, league)
+			}
+		}
+	}
+}
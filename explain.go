@@ -0,0 +1,46 @@
+package gohbem
+
+// Decision reasons reported by ExplainPvPRank.
+const (
+	DecisionIncluded               DecisionReason = "included"
+	DecisionCapped                 DecisionReason = "capped"
+	DecisionLeagueRestricted       DecisionReason = "league_restricted"
+	DecisionBelowIvFloor           DecisionReason = "below_iv_floor"
+	DecisionLittleCupIneligible    DecisionReason = "little_cup_ineligible"
+	DecisionHundoUnderCap          DecisionReason = "hundo_under_cap"
+	DecisionLevelAboveCap          DecisionReason = "level_above_cap"
+	DecisionNotFunctionallyPerfect DecisionReason = "not_functionally_perfect"
+	DecisionEvolution              DecisionReason = "evolution"
+	DecisionCostumeBlocksEvolution DecisionReason = "costume_blocks_evolution"
+	DecisionStatRequirement        DecisionReason = "stat_requirement"
+	DecisionGenderRequirement      DecisionReason = "gender_requirement"
+	DecisionRandomEvolution        DecisionReason = "random_evolution"
+	DecisionItemRequirement        DecisionReason = "item_requirement"
+	DecisionTimeRequirement        DecisionReason = "time_requirement"
+	DecisionUnreleased             DecisionReason = "unreleased"
+	DecisionFormChange             DecisionReason = "form_change"
+	DecisionAboveLeagueCp          DecisionReason = "above_league_cp"
+	DecisionOnlyMaxLevel           DecisionReason = "only_max_level"
+	DecisionTempEvolutionMaxLevel  DecisionReason = "temp_evolution_max_level"
+	DecisionTypesUnknown           DecisionReason = "types_unknown"
+)
+
+// queryTrace is collecting QueryDecision entries, nil trace ignores them.
+type queryTrace struct {
+	decisions []QueryDecision
+}
+
+func (t *queryTrace) add(decision QueryDecision, reason DecisionReason) {
+	if t == nil {
+		return
+	}
+	decision.Reason = reason
+	t.decisions = append(t.decisions, decision)
+}
+
+func (t *queryTrace) addEvolution(edge EvolutionEdge) {
+	if t == nil {
+		return
+	}
+	t.decisions = append(t.decisions, QueryDecision{Pokemon: edge.Pokemon, Form: edge.Form, Target: &edge.Target, Reason: edge.Reason})
+}
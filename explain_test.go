@@ -0,0 +1,94 @@
+package gohbem
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestExplainPvPRank(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var tests = []struct {
+		pokemonId int
+		form      int
+		costume   int
+		gender    int
+		a         int
+		d         int
+		s         int
+		level     float64
+		decision  QueryDecision
+	}{
+		{661, 0, 0, 1, 15, 15, 14, 1, QueryDecision{Pokemon: 661, League: "great", Cap: 50, Reason: DecisionHundoUnderCap}},
+		{661, 0, 0, 1, 15, 15, 14, 1, QueryDecision{Pokemon: 662, League: "little", Reason: DecisionLittleCupIneligible}},
+		{661, 0, 0, 1, 15, 15, 14, 1, QueryDecision{Pokemon: 663, League: "great", Cap: 50, Reason: DecisionCapped}},
+		{661, 0, 0, 1, 15, 15, 14, 1, QueryDecision{Pokemon: 663, League: "great", Reason: DecisionIncluded}},
+		{661, 0, 0, 1, 15, 15, 14, 1, QueryDecision{Pokemon: 661, Target: &Evolution{Pokemon: 662}, Reason: DecisionEvolution}},
+		{663, 0, 0, 1, 15, 15, 15, 40, QueryDecision{Pokemon: 663, League: "great", Cap: 50, Reason: DecisionLevelAboveCap}},
+		{663, 0, 0, 1, 15, 15, 15, 40, QueryDecision{Pokemon: 663, League: "master", Reason: DecisionNotFunctionallyPerfect}},
+		{663, 0, 0, 1, 15, 15, 15, 40, QueryDecision{Pokemon: 663, League: "great", Reason: DecisionAboveLeagueCp}},
+		{3, 0, 0, 1, 15, 15, 14, 1, QueryDecision{Pokemon: 3, Evolution: 1, League: "master", Reason: DecisionTempEvolutionMaxLevel}},
+		{236, 0, 0, 1, 15, 10, 10, 1, QueryDecision{Pokemon: 236, Target: &Evolution{Pokemon: 106, Conditions: DefaultEvolutionRules[0].Conditions}, Reason: DecisionEvolution}},
+		{236, 0, 0, 1, 15, 10, 10, 1, QueryDecision{Pokemon: 236, Target: &Evolution{Pokemon: 107, Conditions: DefaultEvolutionRules[1].Conditions}, Reason: DecisionStatRequirement}},
+		{361, 0, 0, 1, 10, 10, 10, 1, QueryDecision{Pokemon: 361, Target: &Evolution{Pokemon: 478, GenderRequirement: 2}, Reason: DecisionGenderRequirement}},
+		{4, 0, 11, 1, 10, 10, 10, 1, QueryDecision{Pokemon: 4, Target: &Evolution{Pokemon: 5, Form: 175}, Reason: DecisionEvolution}},
+		{4, 0, 12, 1, 10, 10, 10, 1, QueryDecision{Pokemon: 4, Target: &Evolution{Pokemon: 5, Form: 175}, Reason: DecisionCostumeBlocksEvolution}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			entries, decisions, err := ohbem.ExplainPvPRank(test.pokemonId, test.form, test.costume, test.gender, test.a, test.d, test.s, test.level)
+			expected, _ := ohbem.QueryPvPRank(test.pokemonId, test.form, test.costume, test.gender, test.a, test.d, test.s, test.level)
+			sortEntries(entries)
+			sortEntries(expected)
+			if err != nil || !reflect.DeepEqual(entries, expected) {
+				t.Errorf("got %v %+v, want %+v", err, entries, expected)
+			}
+			for _, decision := range decisions {
+				if reflect.DeepEqual(decision, test.decision) {
+					return
+				}
+			}
+			t.Errorf("decisions are missing %+v", test.decision)
+		})
+	}
+
+	ohbem.LevelCaps = []int{MaxLevel}
+	_, decisions, err := ohbem.ExplainPvPRank(661, 0, 0, 1, 0, 0, 0, 52)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	expected := QueryDecision{Pokemon: 661, League: "great", Cap: MaxLevel, Reason: DecisionOnlyMaxLevel}
+	for _, decision := range decisions {
+		if reflect.DeepEqual(decision, expected) {
+			return
+		}
+	}
+	t.Errorf("decisions are missing %+v", expected)
+}
+
+// sortEntries orders entries of every league, temp evolutions are queried in map order.
+func sortEntries(result map[string][]PokemonEntry) {
+	for _, entries := range result {
+		sort.Slice(entries, func(i, j int) bool {
+			a, b := &entries[i], &entries[j]
+			if a.Pokemon != b.Pokemon {
+				return a.Pokemon < b.Pokemon
+			}
+			if a.Form != b.Form {
+				return a.Form < b.Form
+			}
+			if a.Evolution != b.Evolution {
+				return a.Evolution < b.Evolution
+			}
+			return a.Cap < b.Cap
+		})
+	}
+}
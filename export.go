@@ -0,0 +1,195 @@
+package gohbem
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// rankTableColumns are column names of exported rank tables.
+var rankTableColumns = []string{
+	"pokemon", "form", "evolution", "league", "cap", "attack", "defense", "stamina",
+	"level", "cp", "value", "percentage", "rank", "capped",
+}
+
+// RankTable Stream rank table rows selected by query to fn, ordered by species, league, level cap and rank.
+// Uncapped leagues have no rank table and are skipped, as are leagues the species is not eligible for.
+// Rows without level cap (MaxLevel) are not exported, they are equal to or better than rows of the highest level cap.
+func (o *Ohbem) RankTable(query RankTableQuery, fn func(row RankTableRow) error) error {
+	if err := safetyCheck(o); err != nil {
+		return err
+	}
+
+	var leagueNames []string
+	if query.League != "" {
+		if _, ok := o.Leagues[query.League]; !ok {
+			return ErrLeagueMissing
+		}
+		leagueNames = []string{query.League}
+	} else {
+		for leagueName := range o.Leagues {
+			leagueNames = append(leagueNames, leagueName)
+		}
+		sort.Strings(leagueNames)
+	}
+
+	if query.Pokemon != 0 {
+		if _, ok := o.PokemonData.Pokemon[query.Pokemon]; !ok {
+			return ErrMissingPokemon
+		}
+		return o.rankTable(query.Pokemon, query.Form, query.Evolution, leagueNames, query.LevelCap, fn)
+	}
+
+	pokemonIds := make([]int, 0, len(o.PokemonData.Pokemon))
+	for pokemonId := range o.PokemonData.Pokemon {
+		pokemonIds = append(pokemonIds, pokemonId)
+	}
+	sort.Ints(pokemonIds)
+	for _, pokemonId := range pokemonIds {
+		masterPokemon := o.PokemonData.Pokemon[pokemonId]
+		forms := []int{0}
+		if len(masterPokemon.Forms) != 0 {
+			forms = forms[:0]
+			for formId := range masterPokemon.Forms {
+				forms = append(forms, formId)
+			}
+			sort.Ints(forms)
+		}
+		for _, form := range forms {
+			evolutions := []int{0}
+			tempEvolutions := masterPokemon.TempEvolutions
+			if masterForm, ok := masterPokemon.Forms[form]; ok && len(masterForm.TempEvolutions) != 0 {
+				tempEvolutions = masterForm.TempEvolutions
+			}
+			for tempEvoId := range tempEvolutions {
+				evolutions = append(evolutions, tempEvoId)
+			}
+			sort.Ints(evolutions)
+			for _, evolution := range evolutions {
+				if err := o.rankTable(pokemonId, form, evolution, leagueNames, query.LevelCap, fn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// rankTable streams rank tables of one species in provided leagues to fn.
+func (o *Ohbem) rankTable(pokemonId, form, evolution int, leagueNames []string, levelCap int, fn func(row RankTableRow) error) error {
+	stats, err := o.resolveStats(pokemonId, form, evolution)
+	if err != nil {
+		return err
+	}
+	masterPokemon := o.PokemonData.Pokemon[pokemonId]
+	masterForm, _ := resolveForm(&masterPokemon, form)
+	types := o.resolveTypes(pokemonId, form)
+	if len(stats.Types) != 0 {
+		types = stats.Types
+	}
+
+	for _, leagueName := range leagueNames {
+		league := namedLeague(leagueName, o.Leagues[leagueName])
+		if league.IsUncapped() || !league.IsEligible(pokemonId, form, types) {
+			continue
+		}
+		if league.LittleCupRules && !(masterForm.Little || masterPokemon.Little) {
+			continue
+		}
+		combinationIndex, filled := o.calculateAllRanksCompact(&stats, league.Cap, league.levelCaps(o.LevelCaps), league.IvFloor)
+		if !filled {
+			continue
+		}
+		allCaps := make([]int, 0, len(combinationIndex))
+		for lvCap := range combinationIndex {
+			allCaps = append(allCaps, lvCap)
+		}
+		sort.Ints(allCaps)
+		// stats of every cap are needed to tell if combination stays the same at all higher caps
+		capStats := make([]*[4096]PvPRankingStats, len(allCaps))
+		for ix, lvCap := range allCaps {
+			capStats[ix] = new([4096]PvPRankingStats)
+			for index, rank := range combinationIndex[lvCap].Combinations {
+				if rank != 0 {
+					_ = calculatePvPStat(&capStats[ix][index], &stats, index/256, index/16%16, index%16, league.Cap, float64(lvCap), 1)
+				}
+			}
+		}
+
+		for capIx, lvCap := range allCaps {
+			if lvCap >= MaxLevel || (levelCap != 0 && lvCap != levelCap) {
+				continue
+			}
+			combinations := combinationIndex[lvCap]
+			rows := make([]RankTableRow, 0, combinations.Count)
+			for index, rank := range combinations.Combinations {
+				stat := &capStats[capIx][index]
+				if rank == 0 || stat.Level == 0 {
+					continue
+				}
+				capped := true
+				for higherIx := capIx + 1; higherIx < len(allCaps) && capped; higherIx++ {
+					capped = capStats[higherIx][index].Level == stat.Level && combinationIndex[allCaps[higherIx]].Combinations[index] == rank
+				}
+				rows = append(rows, RankTableRow{
+					Pokemon:    pokemonId,
+					Form:       form,
+					Evolution:  evolution,
+					League:     leagueName,
+					Cap:        float64(lvCap),
+					Attack:     index / 256,
+					Defense:    index / 16 % 16,
+					Stamina:    index % 16,
+					Level:      stat.Level,
+					Cp:         stat.Cp,
+					Value:      math.Floor(stat.Value),
+					Percentage: roundFloat(stat.Value/combinations.TopValue, 5),
+					Rank:       rank,
+					Capped:     capped,
+				})
+			}
+			sort.SliceStable(rows, func(i, j int) bool {
+				return rows[i].Rank < rows[j].Rank
+			})
+			for _, row := range rows {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ExportRankTableCSV Write rank table selected by query to w as CSV with header row.
+func (o *Ohbem) ExportRankTableCSV(w io.Writer, query RankTableQuery) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(rankTableColumns); err != nil {
+		return err
+	}
+	record := make([]string, len(rankTableColumns))
+	err := o.RankTable(query, func(row RankTableRow) error {
+		record[0] = strconv.Itoa(row.Pokemon)
+		record[1] = strconv.Itoa(row.Form)
+		record[2] = strconv.Itoa(row.Evolution)
+		record[3] = row.League
+		record[4] = strconv.FormatFloat(row.Cap, 'f', -1, 64)
+		record[5] = strconv.Itoa(row.Attack)
+		record[6] = strconv.Itoa(row.Defense)
+		record[7] = strconv.Itoa(row.Stamina)
+		record[8] = strconv.FormatFloat(row.Level, 'f', -1, 64)
+		record[9] = strconv.Itoa(row.Cp)
+		record[10] = strconv.FormatFloat(row.Value, 'f', -1, 64)
+		record[11] = strconv.FormatFloat(row.Percentage, 'f', -1, 64)
+		record[12] = strconv.Itoa(int(row.Rank))
+		record[13] = strconv.FormatBool(row.Capped)
+		return writer.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,301 @@
+package gohbem
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestExportRankTableCSV(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var tests = []struct {
+		query RankTableQuery
+		rows  int
+		err   error
+	}{
+		{RankTableQuery{Pokemon: 663, League: "great", LevelCap: 50}, 4096, nil},
+		{RankTableQuery{Pokemon: 663, League: "master"}, 0, nil},
+		{RankTableQuery{Pokemon: 663, League: "missing"}, 0, ErrLeagueMissing},
+		{RankTableQuery{Pokemon: 100000}, 0, ErrMissingPokemon},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := ohbem.ExportRankTableCSV(&buf, test.query)
+			if err != test.err {
+				t.Errorf("got %v, want %v", err, test.err)
+			}
+			if test.err != nil {
+				return
+			}
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Errorf("got %v, want nil", err)
+			}
+			if len(records) != test.rows+1 {
+				t.Errorf("got %d rows, want %d", len(records)-1, test.rows)
+			}
+			for ex, column := range rankTableColumns {
+				if records[0][ex] != column {
+					t.Errorf("got column %s, want %s", records[0][ex], column)
+				}
+			}
+			if test.rows == 0 {
+				return
+			}
+			a, _ := strconv.Atoi(records[1][5])
+			d, _ := strconv.Atoi(records[1][6])
+			s, _ := strconv.Atoi(records[1][7])
+			entries, _ := ohbem.QueryPvPRank(663, 0, 0, 0, a, d, s, 1)
+			found := false
+			for _, entry := range entries[test.query.League] {
+				if entry.Cap == float64(test.query.LevelCap) {
+					found = entry.Rank == 1 && records[1][12] == "1" && records[1][9] == strconv.Itoa(entry.Cp)
+				}
+			}
+			if !found {
+				t.Errorf("got %v, want %+v", records[1], entries[test.query.League])
+			}
+		})
+	}
+}
+
+func TestExportRankTableAllSpecies(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	rows, lastPokemon := 0, 0
+	err = ohbem.RankTable(RankTableQuery{League: "little", LevelCap: 50}, func(row RankTableRow) error {
+		rows++
+		if row.Pokemon < lastPokemon {
+			t.Errorf("got %d after %d, want ascending", row.Pokemon, lastPokemon)
+		}
+		lastPokemon = row.Pokemon
+		if row.Rank < 1 {
+			t.Errorf("got %+v, want ranked row", row)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if rows == 0 || rows%4096 != 0 {
+		t.Errorf("got %d rows, want multiple of 4096", rows)
+	}
+}
+
+func TestRankTableCapped(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	rows := make(map[[4]int]RankTableRow)
+	err = ohbem.RankTable(RankTableQuery{Pokemon: 663, League: "great"}, func(row RankTableRow) error {
+		if row.Cap >= MaxLevel {
+			t.Errorf("got %+v, want no row without level cap", row)
+		}
+		rows[[4]int{int(row.Cap), row.Attack, row.Defense, row.Stamina}] = row
+		return nil
+	})
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	var tests = []struct {
+		a int
+		d int
+		s int
+	}{
+		{0, 15, 15},
+		{15, 15, 15},
+		{1, 4, 13},
+		{4, 15, 15},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			entries, _ := ohbem.QueryPvPRank(663, 0, 0, 0, test.a, test.d, test.s, 1)
+			if len(entries["great"]) == 0 {
+				t.Fatalf("got %+v, want great league entries", entries)
+			}
+			for _, entry := range entries["great"] {
+				row := rows[[4]int{int(entry.Cap), test.a, test.d, test.s}]
+				if row.Rank != entry.Rank || row.Level != entry.Level || row.Capped != entry.Capped {
+					t.Errorf("got %+v, want %+v", row, entry)
+				}
+			}
+		})
+	}
+}
+
+// thriftReader is decoding Thrift compact protocol structs into maps of field ids.
+type thriftReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(kind byte) interface{} {
+	switch kind {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 5, 6:
+		return r.zigzag()
+	case 8:
+		n := int(r.varint())
+		r.pos += n
+		return string(r.buf[r.pos-n : r.pos])
+	case 9:
+		header := r.buf[r.pos]
+		r.pos++
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.varint())
+		}
+		list := make([]interface{}, size)
+		for ix := range list {
+			list[ix] = r.value(header & 0x0f)
+		}
+		return list
+	case 12:
+		return r.structure()
+	}
+	panic(fmt.Sprintf("unsupported thrift type %d", kind))
+}
+
+func (r *thriftReader) structure() map[int64]interface{} {
+	result := make(map[int64]interface{})
+	var last int64
+	for {
+		header := r.buf[r.pos]
+		r.pos++
+		if header == 0 {
+			return result
+		}
+		if delta := int64(header >> 4); delta != 0 {
+			last += delta
+		} else {
+			last = r.zigzag()
+		}
+		result[last] = r.value(header & 0x0f)
+	}
+}
+
+func TestExportRankTableParquet(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	query := RankTableQuery{Pokemon: 663, League: "great", LevelCap: 50}
+	var rows []RankTableRow
+	_ = ohbem.RankTable(query, func(row RankTableRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+
+	var buf bytes.Buffer
+	if err := ohbem.ExportRankTableParquet(&buf, query); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	data := buf.Bytes()
+	if string(data[:4]) != "PAR1" || string(data[len(data)-4:]) != "PAR1" {
+		t.Fatalf("got %q and %q, want PAR1", data[:4], data[len(data)-4:])
+	}
+	metaLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	reader := &thriftReader{buf: data[len(data)-8-metaLength : len(data)-8]}
+	meta := reader.structure()
+	if reader.pos != metaLength {
+		t.Errorf("got %d, want %d", reader.pos, metaLength)
+	}
+	if meta[3].(int64) != int64(len(rows)) {
+		t.Errorf("got %d rows, want %d", meta[3], len(rows))
+	}
+	schema := meta[2].([]interface{})
+	if len(schema) != len(rankTableColumns)+1 {
+		t.Errorf("got %d schema elements, want %d", len(schema), len(rankTableColumns)+1)
+	}
+
+	columns := meta[4].([]interface{})[0].(map[int64]interface{})[1].([]interface{})
+	for ix, name := range rankTableColumns {
+		columnMeta := columns[ix].(map[int64]interface{})[3].(map[int64]interface{})
+		if path := columnMeta[3].([]interface{})[0].(string); path != name {
+			t.Errorf("got column %s, want %s", path, name)
+		}
+		offset := int(columnMeta[9].(int64))
+		page := &thriftReader{buf: data[offset:]}
+		header := page.structure()
+		values := data[offset+page.pos : offset+page.pos+int(header[3].(int64))]
+		if header[5].(map[int64]interface{})[1].(int64) != int64(len(rows)) {
+			t.Errorf("%s: got %d values, want %d", name, header[5].(map[int64]interface{})[1], len(rows))
+		}
+		for rx, row := range rows {
+			switch name {
+			case "rank":
+				if v := int16(binary.LittleEndian.Uint32(values[rx*4:])); v != row.Rank {
+					t.Fatalf("row %d: got rank %d, want %d", rx, v, row.Rank)
+				}
+			case "value":
+				if v := math.Float64frombits(binary.LittleEndian.Uint64(values[rx*8:])); v != row.Value {
+					t.Fatalf("row %d: got value %f, want %f", rx, v, row.Value)
+				}
+			case "capped":
+				if v := values[rx/8]&(1<<(rx%8)) != 0; v != row.Capped {
+					t.Fatalf("row %d: got capped %t, want %t", rx, v, row.Capped)
+				}
+			}
+		}
+	}
+}
+
+// TestExportRankTableParquetGolden pins writer output, golden file has to be regenerated when Parquet layout changes on purpose.
+func TestExportRankTableParquetGolden(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{"great": {Cap: 1500, IvFloor: 12}}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var buf bytes.Buffer
+	if err := ohbem.ExportRankTableParquet(&buf, RankTableQuery{Pokemon: 663, League: "great", LevelCap: 50}); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	golden, err := os.ReadFile("./test/rank-table-golden.parquet")
+	if err != nil {
+		t.Fatalf("can't read golden file")
+	}
+	if !bytes.Equal(buf.Bytes(), golden) {
+		t.Errorf("got %d bytes, want %d bytes of golden file", buf.Len(), len(golden))
+	}
+}
@@ -0,0 +1,44 @@
+package gohbem
+
+// Form change kinds.
+const (
+	FormChangeFusion     = "fusion"     // fused with Partner (Kyurem, Necrozma, Calyrex)
+	FormChangeItem       = "item"       // changed out of battle, using Item when set (Giratina, Shaymin, Hoopa)
+	FormChangeGigantamax = "gigantamax" // Gigantamax form with own stats
+)
+
+// FormChanges Return form changes available to Pokemon form, following species form changes when form has none.
+func (o *Ohbem) FormChanges(pokemonId int, form int) ([]FormChange, error) {
+	if err := safetyCheck(o); err != nil {
+		return nil, err
+	}
+	masterPokemon, ok := o.PokemonData.Pokemon[pokemonId]
+	if !ok {
+		return nil, ErrMissingPokemon
+	}
+	masterForm, _ := resolveForm(&masterPokemon, form)
+	return masterForm.FormChanges, nil
+}
+
+// queryFormChanges ranks forms reachable through form changes of masterForm, marking entries with kind of the change.
+// Changed forms don't follow their own form changes, so transitions back to the queried form are not repeated.
+func (o *Ohbem) queryFormChanges(pokemonId int, form int, masterForm *Form, costume int, gender int, attack int, defense int, stamina int, level float64, options QueryOptions, trace *queryTrace) map[string][]PokemonEntry {
+	result := make(map[string][]PokemonEntry)
+	options.noFormChanges = true
+	for _, change := range masterForm.FormChanges {
+		if change.Form == form {
+			continue
+		}
+		trace.add(QueryDecision{Pokemon: pokemonId, Form: form, Target: &Evolution{Pokemon: pokemonId, Form: change.Form}}, DecisionFormChange)
+		changedRanks, _ := o.queryPvPRank(pokemonId, change.Form, costume, gender, attack, defense, stamina, level, options, trace)
+		for leagueName, entries := range changedRanks {
+			for ix := range entries {
+				if entries[ix].FormChange == "" {
+					entries[ix].FormChange = change.Kind
+				}
+			}
+			result[leagueName] = append(result[leagueName], entries...)
+		}
+	}
+	return result
+}
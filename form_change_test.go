@@ -0,0 +1,63 @@
+package gohbem
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestQueryPvPRankFormChanges(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadGameMasterData("./test/gamemaster-test.json", loadGameMasterNames(t)); err != nil {
+		t.Fatalf("LoadGameMasterData returned error: %s", err)
+	}
+
+	var tests = []struct {
+		pokemonId int
+		form      int
+		expected  map[int]string
+	}{
+		{646, 147, map[int]string{147: "", 145: FormChangeFusion, 146: FormChangeFusion}},
+		{646, 0, map[int]string{0: "", 145: FormChangeFusion, 146: FormChangeFusion}},
+		{646, 146, map[int]string{146: ""}},
+		{487, 90, map[int]string{90: "", 91: FormChangeItem}},
+		{487, 91, map[int]string{91: "", 90: FormChangeItem}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			result, err := ohbem.QueryPvPRank(test.pokemonId, test.form, 0, 0, 0, 15, 15, 1)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			forms := make(map[int]string)
+			for _, entry := range result["ultra"] {
+				if kind, ok := forms[entry.Form]; ok && kind != entry.FormChange {
+					t.Errorf("form %d listed with %q and %q", entry.Form, kind, entry.FormChange)
+				}
+				forms[entry.Form] = entry.FormChange
+			}
+			if !reflect.DeepEqual(forms, test.expected) {
+				t.Errorf("got %v, want %v", forms, test.expected)
+			}
+		})
+	}
+
+	changes, err := ohbem.FormChanges(487, 90)
+	if err != nil || !reflect.DeepEqual(changes, []FormChange{{Form: 91, Kind: FormChangeItem, Item: 1604}}) {
+		t.Errorf("got %v %+v", err, changes)
+	}
+	if _, err := ohbem.FormChanges(9999, 0); err != ErrMissingPokemon {
+		t.Errorf("expected ErrMissingPokemon, got %v", err)
+	}
+
+	_, decisions, _ := ohbem.ExplainPvPRank(487, 90, 0, 0, 0, 15, 15, 1)
+	expected := QueryDecision{Pokemon: 487, Form: 90, Target: &Evolution{Pokemon: 487, Form: 91}, Reason: DecisionFormChange}
+	for _, decision := range decisions {
+		if reflect.DeepEqual(decision, expected) {
+			return
+		}
+	}
+	t.Errorf("decisions are missing %+v", expected)
+}
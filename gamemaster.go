@@ -0,0 +1,381 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gameMasterTempEvolutions maps game master temp evolution names to IDs.
+var gameMasterTempEvolutions = map[string]int{
+	"TEMP_EVOLUTION_MEGA":   TempEvolutionMega,
+	"TEMP_EVOLUTION_MEGA_X": TempEvolutionMegaX,
+	"TEMP_EVOLUTION_MEGA_Y": TempEvolutionMegaY,
+	"TEMP_EVOLUTION_PRIMAL": TempEvolutionPrimal,
+}
+
+// gameMasterTypes maps game master type names to Type* IDs.
+var gameMasterTypes = map[string]int{
+	"POKEMON_TYPE_NORMAL": TypeNormal, "POKEMON_TYPE_FIGHTING": TypeFighting, "POKEMON_TYPE_FLYING": TypeFlying,
+	"POKEMON_TYPE_POISON": TypePoison, "POKEMON_TYPE_GROUND": TypeGround, "POKEMON_TYPE_ROCK": TypeRock,
+	"POKEMON_TYPE_BUG": TypeBug, "POKEMON_TYPE_GHOST": TypeGhost, "POKEMON_TYPE_STEEL": TypeSteel,
+	"POKEMON_TYPE_FIRE": TypeFire, "POKEMON_TYPE_WATER": TypeWater, "POKEMON_TYPE_GRASS": TypeGrass,
+	"POKEMON_TYPE_ELECTRIC": TypeElectric, "POKEMON_TYPE_PSYCHIC": TypePsychic, "POKEMON_TYPE_ICE": TypeIce,
+	"POKEMON_TYPE_DRAGON": TypeDragon, "POKEMON_TYPE_DARK": TypeDark, "POKEMON_TYPE_FAIRY": TypeFairy,
+}
+
+// gameMasterGenders maps game master gender names to gender IDs.
+var gameMasterGenders = map[string]int{"MALE": 1, "FEMALE": 2}
+
+var (
+	gameMasterPokemonTemplate = regexp.MustCompile(`^V(\d{4})_POKEMON_`)
+	gameMasterMoveTemplate    = regexp.MustCompile(`^COMBAT_V(\d{4})_MOVE_`)
+)
+
+type gameMasterStats struct {
+	BaseAttack  int `json:"baseAttack"`
+	BaseDefense int `json:"baseDefense"`
+	BaseStamina int `json:"baseStamina"`
+}
+
+type gameMasterEvolutionBranch struct {
+	Evolution                string `json:"evolution"`
+	Form                     string `json:"form"`
+	GenderRequirement        string `json:"genderRequirement"`
+	EvolutionItemRequirement string `json:"evolutionItemRequirement"`
+	OnlyDaytime              bool   `json:"onlyDaytime"`
+	OnlyNighttime            bool   `json:"onlyNighttime"`
+}
+
+type gameMasterFormChange struct {
+	AvailableForm            []string `json:"availableForm"`
+	Item                     string   `json:"item"`
+	ComponentPokemonSettings *struct {
+		PokedexId      string `json:"pokedexId"`
+		FormChangeType string `json:"formChangeType"`
+	} `json:"componentPokemonSettings"`
+}
+
+type gameMasterPokemonSettings struct {
+	PokemonId        string                      `json:"pokemonId"`
+	Form             string                      `json:"form"`
+	Type             string                      `json:"type"`
+	Type2            string                      `json:"type2"`
+	Stats            gameMasterStats             `json:"stats"`
+	QuickMoves       []string                    `json:"quickMoves"`
+	CinematicMoves   []string                    `json:"cinematicMoves"`
+	EvolutionBranch  []gameMasterEvolutionBranch `json:"evolutionBranch"`
+	TempEvoOverrides []struct {
+		TempEvoId     string          `json:"tempEvoId"`
+		Stats         gameMasterStats `json:"stats"`
+		TypeOverride1 string          `json:"typeOverride1"`
+		TypeOverride2 string          `json:"typeOverride2"`
+	} `json:"tempEvoOverrides"`
+	FormChange []gameMasterFormChange `json:"formChange"`
+}
+
+type gameMasterTemplate struct {
+	TemplateId string `json:"templateId"`
+	Data       struct {
+		PokemonSettings *gameMasterPokemonSettings `json:"pokemonSettings"`
+		FormSettings    *struct {
+			Pokemon string `json:"pokemon"`
+			Forms   []struct {
+				Form      string `json:"form"`
+				IsCostume bool   `json:"isCostume"`
+			} `json:"forms"`
+		} `json:"formSettings"`
+		CombatMove *struct {
+			UniqueId      string  `json:"uniqueId"`
+			Type          string  `json:"type"`
+			Power         float64 `json:"power"`
+			EnergyDelta   int     `json:"energyDelta"`
+			DurationTurns int     `json:"durationTurns"`
+		} `json:"combatMove"`
+	} `json:"data"`
+}
+
+// gameMasterName converts game master name like "MR_MIME" into "Mr Mime".
+func gameMasterName(name string) string {
+	words := strings.Split(strings.ToLower(name), "_")
+	for ix, word := range words {
+		if word != "" {
+			words[ix] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// gameMasterFormName returns name of form without species, like "Alola" for "MEOWTH_ALOLA", "" for normal forms.
+func gameMasterFormName(pokemon, form string) string {
+	form = strings.TrimPrefix(form, pokemon+"_")
+	if form == "NORMAL" {
+		return ""
+	}
+	return gameMasterName(form)
+}
+
+// ConvertGameMaster Convert raw Pokémon GO game master JSON into PokemonData.
+// Pokémon and move IDs are taken from template IDs, form, costume and item IDs from names.
+// Pokémon and form names are derived from game master names, like "Mr Mime" or "Alola", forms ending with "_SHADOW" are marked Shadow.
+// Pokémon evolving into something, while not being an evolution of anything, are marked Little.
+func ConvertGameMaster(raw []byte, names GameMasterNames) (PokemonData, error) {
+	data := PokemonData{
+		Pokemon:  make(map[int]Pokemon),
+		Costumes: make(map[int]bool),
+		Moves:    make(map[int]Move),
+	}
+
+	var templates []gameMasterTemplate
+	if err := json.Unmarshal(raw, &templates); err != nil {
+		return data, ErrGameMasterUnmarshall
+	}
+
+	pokemonIds := make(map[string]int)
+	moveIds := make(map[string]int)
+	for _, template := range templates {
+		if match := gameMasterPokemonTemplate.FindStringSubmatch(template.TemplateId); match != nil && template.Data.PokemonSettings != nil {
+			pokemonIds[template.Data.PokemonSettings.PokemonId], _ = strconv.Atoi(match[1])
+		}
+		if match := gameMasterMoveTemplate.FindStringSubmatch(template.TemplateId); match != nil && template.Data.CombatMove != nil {
+			moveId, _ := strconv.Atoi(match[1])
+			move := template.Data.CombatMove
+			moveIds[move.UniqueId] = moveId
+			entry := Move{Name: move.UniqueId, Type: gameMasterTypes[move.Type], Power: int(move.Power), Energy: move.EnergyDelta}
+			if strings.HasSuffix(move.UniqueId, "_FAST") {
+				entry.Turns = move.DurationTurns + 1
+			} else if entry.Energy < 0 {
+				entry.Energy = -entry.Energy
+			}
+			data.Moves[moveId] = entry
+		}
+	}
+
+	convertMoves := func(moves []string) []int {
+		var result []int
+		for _, move := range moves {
+			if moveId, ok := moveIds[move]; ok {
+				result = append(result, moveId)
+			}
+		}
+		return result
+	}
+	convertTypes := func(settings *gameMasterPokemonSettings) []int {
+		var result []int
+		for _, name := range []string{settings.Type, settings.Type2} {
+			if typeId, ok := gameMasterTypes[name]; ok {
+				result = append(result, typeId)
+			}
+		}
+		return result
+	}
+	convertEvolutions := func(branches []gameMasterEvolutionBranch) []Evolution {
+		var result []Evolution
+		for _, branch := range branches {
+			pokemonId, ok := pokemonIds[branch.Evolution]
+			if !ok {
+				continue // temp evolution branches have no evolution
+			}
+			evolution := Evolution{Pokemon: pokemonId, Form: names.Forms[branch.Form], GenderRequirement: gameMasterGenders[branch.GenderRequirement]}
+			if branch.EvolutionItemRequirement != "" {
+				evolution.Conditions = append(evolution.Conditions, EvolutionCondition{Kind: EvolutionConditionItem, Item: names.Items[branch.EvolutionItemRequirement]})
+			}
+			if branch.OnlyDaytime {
+				evolution.Conditions = append(evolution.Conditions, EvolutionCondition{Kind: EvolutionConditionTimeOfDay, TimeOfDay: "day"})
+			} else if branch.OnlyNighttime {
+				evolution.Conditions = append(evolution.Conditions, EvolutionCondition{Kind: EvolutionConditionTimeOfDay, TimeOfDay: "night"})
+			}
+			result = append(result, evolution)
+		}
+		return result
+	}
+	convertTempEvolutions := func(settings *gameMasterPokemonSettings) map[int]PokemonStats {
+		var result map[int]PokemonStats
+		for _, override := range settings.TempEvoOverrides {
+			tempEvoId, ok := gameMasterTempEvolutions[override.TempEvoId]
+			if !ok || override.Stats.BaseAttack == 0 {
+				continue
+			}
+			if result == nil {
+				result = make(map[int]PokemonStats)
+			}
+			stats := PokemonStats{Attack: override.Stats.BaseAttack, Defense: override.Stats.BaseDefense, Stamina: override.Stats.BaseStamina}
+			if types := convertTypes(&gameMasterPokemonSettings{Type: override.TypeOverride1, Type2: override.TypeOverride2}); !equalInts(types, convertTypes(settings)) {
+				stats.Types = types
+			}
+			result[tempEvoId] = stats
+		}
+		return result
+	}
+
+	convertFormChanges := func(changes []gameMasterFormChange) []FormChange {
+		var result []FormChange
+		for _, change := range changes {
+			formChange := FormChange{Kind: FormChangeItem, Item: names.Items[change.Item]}
+			if component := change.ComponentPokemonSettings; component != nil {
+				if component.FormChangeType != "FUSE" {
+					continue // unfusing returns to the form fusion started from
+				}
+				formChange.Kind = FormChangeFusion
+				formChange.Partner = pokemonIds[component.PokedexId]
+			}
+			for _, form := range change.AvailableForm {
+				formId, ok := names.Forms[form]
+				if !ok {
+					continue
+				}
+				formChange.Form = formId
+				if strings.HasSuffix(form, "_GIGANTAMAX") {
+					formChange.Kind = FormChangeGigantamax
+				}
+				result = append(result, formChange)
+			}
+		}
+		return result
+	}
+
+	// species first, so forms can be compared against them
+	var forms []*gameMasterPokemonSettings
+	for _, template := range templates {
+		settings := template.Data.PokemonSettings
+		if settings == nil || !gameMasterPokemonTemplate.MatchString(template.TemplateId) {
+			continue
+		}
+		if settings.Form != "" {
+			forms = append(forms, settings)
+			continue
+		}
+		data.Pokemon[pokemonIds[settings.PokemonId]] = Pokemon{
+			Name:           gameMasterName(settings.PokemonId),
+			Attack:         settings.Stats.BaseAttack,
+			Defense:        settings.Stats.BaseDefense,
+			Stamina:        settings.Stats.BaseStamina,
+			Types:          convertTypes(settings),
+			FastMoves:      convertMoves(settings.QuickMoves),
+			ChargedMoves:   convertMoves(settings.CinematicMoves),
+			Evolutions:     convertEvolutions(settings.EvolutionBranch),
+			TempEvolutions: convertTempEvolutions(settings),
+			FormChanges:    convertFormChanges(settings.FormChange),
+			Forms:          make(map[int]Form),
+		}
+	}
+	for _, settings := range forms {
+		formId, ok := names.Forms[settings.Form]
+		masterPokemon, exists := data.Pokemon[pokemonIds[settings.PokemonId]]
+		if !ok || !exists {
+			continue
+		}
+		form := Form{
+			Name:           gameMasterFormName(settings.PokemonId, settings.Form),
+			Shadow:         strings.HasSuffix(settings.Form, "_SHADOW"),
+			FastMoves:      convertMoves(settings.QuickMoves),
+			ChargedMoves:   convertMoves(settings.CinematicMoves),
+			Evolutions:     convertEvolutions(settings.EvolutionBranch),
+			TempEvolutions: convertTempEvolutions(settings),
+			FormChanges:    convertFormChanges(settings.FormChange),
+		}
+		if settings.Stats.BaseAttack != masterPokemon.Attack || settings.Stats.BaseDefense != masterPokemon.Defense || settings.Stats.BaseStamina != masterPokemon.Stamina {
+			form.Attack, form.Defense, form.Stamina = settings.Stats.BaseAttack, settings.Stats.BaseDefense, settings.Stats.BaseStamina
+		}
+		if types := convertTypes(settings); !equalInts(types, masterPokemon.Types) {
+			form.Types = types
+		}
+		masterPokemon.Forms[formId] = form
+	}
+
+	// forms without own settings share species evolutions, costume forms can't evolve
+	for _, template := range templates {
+		settings := template.Data.FormSettings
+		if settings == nil {
+			continue
+		}
+		masterPokemon, ok := data.Pokemon[pokemonIds[settings.Pokemon]]
+		if !ok {
+			continue
+		}
+		for _, form := range settings.Forms {
+			formId, ok := names.Forms[form.Form]
+			if _, exists := masterPokemon.Forms[formId]; !ok || exists {
+				continue
+			}
+			name, shadow := gameMasterFormName(settings.Pokemon, form.Form), strings.HasSuffix(form.Form, "_SHADOW")
+			if form.IsCostume {
+				masterPokemon.Forms[formId] = Form{Name: name, Shadow: shadow}
+			} else {
+				masterPokemon.Forms[formId] = Form{Name: name, Shadow: shadow, Evolutions: masterPokemon.Evolutions, TempEvolutions: masterPokemon.TempEvolutions}
+			}
+		}
+	}
+	for name, costumeId := range names.Costumes {
+		data.Costumes[costumeId] = strings.HasSuffix(name, "_NOEVOLVE")
+	}
+
+	markLittle(&data)
+	data.Initialized = true
+	return data, nil
+}
+
+// markLittle marks Pokemon evolving into something, while not being an evolution of anything, as Little.
+func markLittle(data *PokemonData) {
+	evolved := make(map[int]bool)
+	for _, masterPokemon := range data.Pokemon {
+		for _, evolution := range masterPokemon.Evolutions {
+			evolved[evolution.Pokemon] = true
+		}
+	}
+	for pokemonId, masterPokemon := range data.Pokemon {
+		if len(masterPokemon.Evolutions) != 0 && !evolved[pokemonId] {
+			masterPokemon.Little = true
+			data.Pokemon[pokemonId] = masterPokemon
+		}
+	}
+}
+
+// equalInts returns true when both slices have the same values in the same order.
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for ix := range a {
+		if a[ix] != b[ix] {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadGameMasterData Load raw game master from provided filePath, convert it and keep it in memory.
+func (o *Ohbem) LoadGameMasterData(filePath string, names GameMasterNames) error {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return ErrGameMasterOpen
+	}
+	pokemonData, err := ConvertGameMaster(raw, names)
+	if err != nil {
+		return err
+	}
+	o.usePokemonData(pokemonData, MasterFileSourceFile, fileModTime(filePath))
+	return nil
+}
+
+// usePokemonData replaces PokemonData with MasterFile loaded from source, last updated at updatedAt.
+func (o *Ohbem) usePokemonData(pokemonData PokemonData, source string, updatedAt time.Time) {
+	pokemonData.Initialized = true
+	if pokemonData.raw == nil {
+		pokemonData.raw, _ = json.Marshal(pokemonData)
+	}
+	o.preparePokemonData(&pokemonData)
+	o.swapPokemonData(pokemonData)
+	o.setMasterFileStatus(source, updatedAt)
+	o.PruneCache()
+}
+
+// swapPokemonData replaces PokemonData, waiting for running queries to finish.
+func (o *Ohbem) swapPokemonData(pokemonData PokemonData) {
+	o.pokemonDataMutex.Lock()
+	defer o.pokemonDataMutex.Unlock()
+	o.PokemonData = pokemonData
+}
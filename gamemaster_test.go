@@ -0,0 +1,110 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func loadGameMasterNames(t *testing.T) GameMasterNames {
+	var names GameMasterNames
+	raw, err := os.ReadFile("./test/gamemaster-names-test.json")
+	if err != nil {
+		t.Fatalf("can't load game master names")
+	}
+	if err := json.Unmarshal(raw, &names); err != nil {
+		t.Fatalf("can't unmarshal game master names")
+	}
+	return names
+}
+
+func TestConvertGameMaster(t *testing.T) {
+	raw, err := os.ReadFile("./test/gamemaster-test.json")
+	if err != nil {
+		t.Fatalf("can't load game master")
+	}
+	data, err := ConvertGameMaster(raw, loadGameMasterNames(t))
+	if err != nil {
+		t.Fatalf("ConvertGameMaster returned error: %s", err)
+	}
+
+	var tests = []struct {
+		got      interface{}
+		expected interface{}
+	}{
+		{len(data.Pokemon), 15},
+		{data.Pokemon[1].Attack, 118},
+		{data.Pokemon[1].Name, "Bulbasaur"},
+		{data.Pokemon[1].Forms[163].Name, ""},
+		{data.Pokemon[1].Forms[897].Name, "Fall 2019"},
+		{data.Pokemon[1].Forms[164].Shadow, true},
+		{data.Pokemon[1].Forms[163].Shadow, false},
+		{data.Pokemon[1].Types, []int{TypeGrass, TypePoison}},
+		{data.Pokemon[1].FastMoves, []int{214}},
+		{data.Pokemon[1].ChargedMoves, []int{90}},
+		{data.Pokemon[1].Little, true},
+		{data.Pokemon[2].Little, false},
+		{data.Pokemon[1].Evolutions, []Evolution{{Pokemon: 2, Form: 166}}},
+		{data.Pokemon[1].Forms[163].Evolutions, []Evolution{{Pokemon: 2, Form: 166}}},
+		{data.Pokemon[1].Forms[163].Attack, 0},
+		{data.Pokemon[1].Forms[897].Evolutions, []Evolution(nil)},
+		{data.Pokemon[3].Evolutions, []Evolution(nil)},
+		{data.Pokemon[3].TempEvolutions, map[int]PokemonStats{1: {Attack: 241, Defense: 246, Stamina: 190}}},
+		{data.Pokemon[52].Forms[64].Attack, 99},
+		{data.Pokemon[52].Forms[64].Name, "Alola"},
+		{data.Pokemon[52].Forms[64].Types, []int{TypeDark}},
+		{data.Pokemon[52].Forms[64].Evolutions, []Evolution{{Pokemon: 53, Form: 65}}},
+		{data.Pokemon[133].Evolutions, []Evolution{
+			{Pokemon: 196, Conditions: []EvolutionCondition{{Kind: EvolutionConditionTimeOfDay, TimeOfDay: "day"}}},
+			{Pokemon: 197, Conditions: []EvolutionCondition{{Kind: EvolutionConditionTimeOfDay, TimeOfDay: "night"}}},
+		}},
+		{data.Pokemon[361].Evolutions, []Evolution{
+			{Pokemon: 362},
+			{Pokemon: 478, GenderRequirement: 2, Conditions: []EvolutionCondition{{Kind: EvolutionConditionItem, Item: 1106}}},
+		}},
+		{data.Moves[214], Move{Name: "VINE_WHIP_FAST", Type: TypeGrass, Power: 5, Energy: 8, Turns: 2}},
+		{data.Moves[90], Move{Name: "SLUDGE_BOMB", Type: TypePoison, Power: 80, Energy: 50}},
+		{data.Costumes, map[int]bool{1: false, 25: true}},
+		{data.Pokemon[487].Forms[90].FormChanges, []FormChange{{Form: 91, Kind: FormChangeItem, Item: 1604}}},
+		{data.Pokemon[487].Forms[91].FormChanges, []FormChange{{Form: 90, Kind: FormChangeItem}}},
+		{data.Pokemon[487].Forms[91].Attack, 225},
+		{data.Pokemon[646].FormChanges, []FormChange{{Form: 145, Kind: FormChangeFusion, Partner: 643}, {Form: 146, Kind: FormChangeFusion, Partner: 644}}},
+		{data.Pokemon[646].Forms[146].FormChanges, []FormChange(nil)},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if !reflect.DeepEqual(test.got, test.expected) {
+				t.Errorf("got %+v, want %+v", test.got, test.expected)
+			}
+		})
+	}
+
+	if _, err := ConvertGameMaster([]byte("{"), GameMasterNames{}); err != ErrGameMasterUnmarshall {
+		t.Errorf("expected ErrGameMasterUnmarshall, got %v", err)
+	}
+}
+
+func TestLoadGameMasterData(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadGameMasterData("./test/gamemaster-test.json", loadGameMasterNames(t)); err != nil {
+		t.Fatalf("LoadGameMasterData returned error: %s", err)
+	}
+	entries, err := ohbem.QueryPvPRank(361, 0, 0, 2, 0, 15, 15, 1)
+	if err != nil {
+		t.Errorf("QueryPvPRank returned error: %s", err)
+	}
+	found := make(map[int]bool)
+	for _, entry := range entries["great"] {
+		found[entry.Pokemon] = true
+	}
+	if !found[362] || !found[478] {
+		t.Errorf("expected female Snorunt evolutions in great league, got %v", found)
+	}
+	if err := ohbem.LoadGameMasterData("./test/missing.json", GameMasterNames{}); err != ErrGameMasterOpen {
+		t.Errorf("expected ErrGameMasterOpen, got %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package gohbem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMasterFileHistorySize is count of kept snapshots when MasterFileHistorySize is not provided.
+const defaultMasterFileHistorySize = 10
+
+// masterFileSnapshotPrefix and masterFileSnapshotSuffix surround "<unix nano>-<fingerprint>" in snapshot file names.
+const (
+	masterFileSnapshotPrefix = "masterfile-"
+	masterFileSnapshotSuffix = ".json"
+)
+
+// masterFileRejectedName is file in MasterFileHistoryPath holding fingerprints rejected by RollbackPokemonData.
+const masterFileRejectedName = "rejected.json"
+
+// writeFileAtomic writes data to temporary file in the same directory and renames it over filePath.
+func writeFileAtomic(filePath string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// rawPokemonData returns MasterFile of PokemonData as it was loaded, serialized PokemonData when it was assigned directly.
+func rawPokemonData(data *PokemonData) ([]byte, error) {
+	if data.raw != nil {
+		return data.raw, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, ErrMasterFileMarshall
+	}
+	return raw, nil
+}
+
+// fingerprintMasterFile returns short SHA-256 fingerprint of raw MasterFile.
+func fingerprintMasterFile(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// MasterFileFingerprint returns fingerprint of MasterFile in memory, as used by MasterFileVersion.
+// It's computed from MasterFile as loaded, so it doesn't depend on EvolutionRules.
+func (o *Ohbem) MasterFileFingerprint() (string, error) {
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	raw, err := rawPokemonData(&o.PokemonData)
+	if err != nil {
+		return "", err
+	}
+	return fingerprintMasterFile(raw), nil
+}
+
+// SnapshotPokemonData Store PokemonData from memory as new version in MasterFileHistoryPath, dropping the oldest versions over MasterFileHistorySize.
+// Snapshot is not duplicated when the newest version has the same fingerprint.
+func (o *Ohbem) SnapshotPokemonData() (MasterFileVersion, error) {
+	var version MasterFileVersion
+
+	if o.MasterFileHistoryPath == "" {
+		return version, ErrMasterFileHistoryDisabled
+	}
+	o.pokemonDataMutex.RLock()
+	raw, err := rawPokemonData(&o.PokemonData)
+	o.pokemonDataMutex.RUnlock()
+	if err != nil {
+		return version, err
+	}
+	fingerprint := fingerprintMasterFile(raw)
+	versions, err := o.ListMasterFileVersions()
+	if err != nil {
+		return version, err
+	}
+	if len(versions) != 0 && versions[0].Fingerprint == fingerprint {
+		return versions[0], nil
+	}
+
+	if err := os.MkdirAll(o.MasterFileHistoryPath, 0755); err != nil {
+		return version, ErrMasterFileSave
+	}
+	version.Fingerprint = fingerprint
+	version.FetchedAt = time.Now()
+	version.Path = filepath.Join(o.MasterFileHistoryPath,
+		fmt.Sprintf("%s%d-%s%s", masterFileSnapshotPrefix, version.FetchedAt.UnixNano(), fingerprint, masterFileSnapshotSuffix))
+	if err := writeFileAtomic(version.Path, raw); err != nil {
+		return version, ErrMasterFileSave
+	}
+
+	size := o.MasterFileHistorySize
+	if size <= 0 {
+		size = defaultMasterFileHistorySize
+	}
+	versions = append([]MasterFileVersion{version}, versions...)
+	for _, old := range versions[min(size, len(versions)):] {
+		if err := os.Remove(old.Path); err != nil {
+			o.log(fmt.Sprintf("Removing MasterFile snapshot %s has failed!", old.Path))
+		}
+	}
+	return version, nil
+}
+
+// ListMasterFileVersions List MasterFile snapshots kept in MasterFileHistoryPath, newest first.
+func (o *Ohbem) ListMasterFileVersions() ([]MasterFileVersion, error) {
+	var versions []MasterFileVersion
+
+	if o.MasterFileHistoryPath == "" {
+		return versions, ErrMasterFileHistoryDisabled
+	}
+	files, err := os.ReadDir(o.MasterFileHistoryPath)
+	if os.IsNotExist(err) {
+		return versions, nil
+	} else if err != nil {
+		return versions, ErrMasterFileOpen
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, masterFileSnapshotPrefix) || !strings.HasSuffix(name, masterFileSnapshotSuffix) {
+			continue
+		}
+		fetchedAt, fingerprint, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(name, masterFileSnapshotPrefix), masterFileSnapshotSuffix), "-")
+		if !ok {
+			continue
+		}
+		nano, err := strconv.ParseInt(fetchedAt, 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, MasterFileVersion{
+			Fingerprint: fingerprint,
+			FetchedAt:   time.Unix(0, nano),
+			Path:        filepath.Join(o.MasterFileHistoryPath, name),
+		})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].FetchedAt.After(versions[j].FetchedAt)
+	})
+	return versions, nil
+}
+
+// RollbackPokemonData Replace PokemonData with MasterFile snapshot of provided fingerprint, and store it in MasterFileCachePath when provided.
+// Fingerprint of replaced PokemonData is rejected by watcher, so the bad upstream MasterFile is not applied again, also after restart.
+// Watcher refreshes wait until rollback is finished.
+func (o *Ohbem) RollbackPokemonData(fingerprint string) error {
+	o.refreshMutex.Lock()
+	defer o.refreshMutex.Unlock()
+
+	versions, err := o.ListMasterFileVersions()
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if version.Fingerprint != fingerprint {
+			continue
+		}
+		current, err := o.MasterFileFingerprint()
+		if err != nil {
+			return err
+		}
+		raw, err := os.ReadFile(version.Path)
+		if err != nil {
+			return ErrMasterFileOpen
+		}
+		if err := o.LoadPokemonData(version.Path); err != nil {
+			return err
+		}
+		o.setMasterFileStatus(MasterFileSourceSnapshot, version.FetchedAt)
+		if o.MasterFileCachePath != "" {
+			if err := writeFileAtomic(o.MasterFileCachePath, raw); err != nil {
+				return ErrMasterFileSave
+			}
+		}
+		if current != fingerprint {
+			if err := o.rejectFingerprint(current); err != nil {
+				return err
+			}
+		}
+		o.log(fmt.Sprintf("PokemonData rolled back to MasterFile %s", fingerprint))
+		return nil
+	}
+	return ErrMasterFileVersionMissing
+}
+
+// rejectedFingerprints returns set of fingerprints stored in MasterFileHistoryPath.
+func (o *Ohbem) rejectedFingerprints() (map[string]bool, error) {
+	rejected := make(map[string]bool)
+	if o.MasterFileHistoryPath == "" {
+		return rejected, nil
+	}
+	data, err := os.ReadFile(filepath.Join(o.MasterFileHistoryPath, masterFileRejectedName))
+	if os.IsNotExist(err) {
+		return rejected, nil
+	} else if err != nil {
+		return rejected, ErrMasterFileOpen
+	}
+	var fingerprints []string
+	if err := json.Unmarshal(data, &fingerprints); err != nil {
+		return rejected, ErrMasterFileUnmarshall
+	}
+	for _, fingerprint := range fingerprints {
+		rejected[fingerprint] = true
+	}
+	return rejected, nil
+}
+
+// rejectFingerprint adds fingerprint to set stored in MasterFileHistoryPath.
+func (o *Ohbem) rejectFingerprint(fingerprint string) error {
+	rejected, err := o.rejectedFingerprints()
+	if err != nil {
+		return err
+	}
+	if rejected[fingerprint] {
+		return nil
+	}
+	rejected[fingerprint] = true
+	fingerprints := make([]string, 0, len(rejected))
+	for rejectedFingerprint := range rejected {
+		fingerprints = append(fingerprints, rejectedFingerprint)
+	}
+	sort.Strings(fingerprints)
+	data, err := json.Marshal(fingerprints)
+	if err != nil {
+		return ErrMasterFileMarshall
+	}
+	if err := writeFileAtomic(filepath.Join(o.MasterFileHistoryPath, masterFileRejectedName), data); err != nil {
+		return ErrMasterFileSave
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package gohbem
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMasterFileHistory(t *testing.T) {
+	dir := t.TempDir()
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileHistoryPath: filepath.Join(dir, "history"), MasterFileHistorySize: 2, MasterFileCachePath: filepath.Join(dir, "cache.json")}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	if versions, err := ohbem.ListMasterFileVersions(); err != nil || len(versions) != 0 {
+		t.Errorf("got %v %v, want no versions", versions, err)
+	}
+
+	first, err := ohbem.SnapshotPokemonData()
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if again, _ := ohbem.SnapshotPokemonData(); again.Path != first.Path || !again.FetchedAt.Equal(first.FetchedAt) {
+		t.Errorf("got %+v, want %+v", again, first)
+	}
+	if fingerprint, _ := ohbem.MasterFileFingerprint(); fingerprint != first.Fingerprint {
+		t.Errorf("got fingerprint %s, want %s", fingerprint, first.Fingerprint)
+	}
+
+	// fingerprint is computed from MasterFile as loaded, evolution rules don't change it
+	if err := ohbem.LoadEvolutionRules("./test/evolution-rules-test.json"); err != nil {
+		t.Fatalf("can't load EvolutionRules: %v", err)
+	}
+	if fingerprint, _ := ohbem.MasterFileFingerprint(); fingerprint != first.Fingerprint {
+		t.Errorf("got fingerprint %s with EvolutionRules, want %s", fingerprint, first.Fingerprint)
+	}
+
+	delete(ohbem.PokemonData.Pokemon, 1)
+	loadModified(t, &ohbem, filepath.Join(dir, "second.json"))
+	second, _ := ohbem.SnapshotPokemonData()
+	delete(ohbem.PokemonData.Pokemon, 2)
+	loadModified(t, &ohbem, filepath.Join(dir, "third.json"))
+	third, _ := ohbem.SnapshotPokemonData()
+	if first.Fingerprint == second.Fingerprint || second.Fingerprint == third.Fingerprint {
+		t.Errorf("got %s %s %s, want different fingerprints", first.Fingerprint, second.Fingerprint, third.Fingerprint)
+	}
+
+	versions, err := ohbem.ListMasterFileVersions()
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if len(versions) != 2 || versions[0].Fingerprint != third.Fingerprint || versions[1].Fingerprint != second.Fingerprint {
+		t.Errorf("got %+v, want %+v", versions, []MasterFileVersion{third, second})
+	}
+
+	if err := ohbem.RollbackPokemonData(first.Fingerprint); err != ErrMasterFileVersionMissing {
+		t.Errorf("got %v, want %v", err, ErrMasterFileVersionMissing)
+	}
+	if err := ohbem.RollbackPokemonData(second.Fingerprint); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if _, ok := ohbem.PokemonData.Pokemon[2]; !ok {
+		t.Errorf("got no Ivysaur, want rolled back PokemonData")
+	}
+	if _, ok := ohbem.PokemonData.Pokemon[1]; ok {
+		t.Errorf("got Bulbasaur, want rolled back PokemonData")
+	}
+	if fingerprint, _ := ohbem.MasterFileFingerprint(); fingerprint != second.Fingerprint {
+		t.Errorf("got fingerprint %s after rollback, want %s", fingerprint, second.Fingerprint)
+	}
+	cached, _ := os.ReadFile(ohbem.MasterFileCachePath)
+	if snapshot, _ := os.ReadFile(second.Path); !bytes.Equal(cached, snapshot) {
+		t.Errorf("got %d bytes in cache, want %d bytes of snapshot", len(cached), len(snapshot))
+	}
+	if rejected, err := ohbem.rejectedFingerprints(); err != nil || len(rejected) != 1 || !rejected[third.Fingerprint] {
+		t.Errorf("got %v %v, want %s rejected", rejected, err, third.Fingerprint)
+	}
+
+	// rejected MasterFile isn't applied by new instance serving the same history
+	remote, _ := os.ReadFile(third.Path)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(remote)
+	}))
+	defer server.Close()
+	restarted := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileHistoryPath: ohbem.MasterFileHistoryPath, MasterFileCachePath: ohbem.MasterFileCachePath, RemoteMasterFileURL: server.URL}
+	if err := restarted.LoadPokemonData(restarted.MasterFileCachePath); err != nil {
+		t.Errorf("can't load cached MasterFile")
+	}
+	if replaced, err := restarted.refreshPokemonData(); replaced || err != nil {
+		t.Errorf("got %t %v, want rejected MasterFile skipped", replaced, err)
+	}
+	if fingerprint, _ := restarted.MasterFileFingerprint(); fingerprint != second.Fingerprint {
+		t.Errorf("got fingerprint %s, want %s", fingerprint, second.Fingerprint)
+	}
+
+	disabled := Ohbem{}
+	if _, err := disabled.ListMasterFileVersions(); err != ErrMasterFileHistoryDisabled {
+		t.Errorf("got %v, want %v", err, ErrMasterFileHistoryDisabled)
+	}
+}
+
+// loadModified loads PokemonData modified in memory as new MasterFile, stored under filePath.
+func loadModified(t *testing.T, ohbem *Ohbem, filePath string) {
+	ohbem.PokemonData.raw = nil
+	raw, err := json.Marshal(ohbem.PokemonData)
+	if err != nil {
+		t.Fatalf("can't marshal PokemonData")
+	}
+	if err := os.WriteFile(filePath, raw, 0644); err != nil {
+		t.Fatalf("can't write MasterFile")
+	}
+	if err := ohbem.LoadPokemonData(filePath); err != nil {
+		t.Fatalf("can't load MasterFile")
+	}
+}
+
+func TestSavePokemonDataAtomic(t *testing.T) {
+	dir := t.TempDir()
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	filePath := filepath.Join(dir, "master.json")
+	if err := os.WriteFile(filePath, []byte("old"), 0644); err != nil {
+		t.Fatalf("can't write file")
+	}
+	if err := ohbem.SavePokemonData(filePath); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("got %d files, want 1", len(files))
+	}
+
+	loaded := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := loaded.LoadPokemonData(filePath); err != nil {
+		t.Errorf("can't load saved MasterFile: %s", err)
+	}
+	if len(loaded.PokemonData.Pokemon) != len(ohbem.PokemonData.Pokemon) {
+		t.Errorf("got %d Pokemon, want %d", len(loaded.PokemonData.Pokemon), len(ohbem.PokemonData.Pokemon))
+	}
+}
+
+func TestRollbackPokemonDataWaitsForRefresh(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileHistoryPath: t.TempDir()}
+	if err := ohbem.LoadPokemonData("./test/master-test.json"); err != nil {
+		t.Fatalf("can't load MasterFile")
+	}
+	version, err := ohbem.SnapshotPokemonData()
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+
+	ohbem.refreshMutex.Lock()
+	done := make(chan error)
+	go func() {
+		done <- ohbem.RollbackPokemonData(version.Fingerprint)
+	}()
+	select {
+	case err := <-done:
+		t.Errorf("got %v during refresh, want rollback to wait", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	ohbem.refreshMutex.Unlock()
+	if err := <-done; err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+}
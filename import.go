@@ -0,0 +1,498 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pogoApiFiles are names of pogoapi files read by LoadPogoApiData, only stats file is required.
+const (
+	pogoApiStatsFile      = "pokemon_stats.json"
+	pogoApiTypesFile      = "pokemon_types.json"
+	pogoApiEvolutionsFile = "pokemon_evolutions.json"
+	pogoApiMegaFile       = "mega_pokemon.json"
+)
+
+// importTempEvolutionSuffixes maps species suffixes of temp evolutions to their IDs, longest first.
+var importTempEvolutionSuffixes = []struct {
+	suffix    string
+	evolution int
+}{
+	{"_mega_x", TempEvolutionMegaX},
+	{"_mega_y", TempEvolutionMegaY},
+	{"_mega", TempEvolutionMega},
+	{"_primal", TempEvolutionPrimal},
+}
+
+// importGenders maps pogoapi gender names to gender IDs.
+var importGenders = map[string]int{"male": 1, "female": 2}
+
+// typeByName returns Type* ID of type name like "grass" or "Grass", 0 when unknown.
+func typeByName(name string) int {
+	return gameMasterTypes["POKEMON_TYPE_"+strings.ToUpper(name)]
+}
+
+// typesByName converts type names to Type* IDs, skipping unknown ones.
+func typesByName(names []string) []int {
+	var result []int
+	for _, name := range names {
+		if typeId := typeByName(name); typeId != 0 {
+			result = append(result, typeId)
+		}
+	}
+	return result
+}
+
+// importIgnoredSuffixes are species key suffixes of forms importers skip on purpose, unless mapped.
+var importIgnoredSuffixes = []string{"_shadow", "_purified"}
+
+// resolveSpecies resolves species key through mapping, falling back to dex number for base species and temp evolutions.
+// Base species are recognized by importers from their source data, not from the key, as names may contain underscores.
+func (m *ImportMapping) resolveSpecies(key string, dex int, base bool) (SpeciesId, bool) {
+	key = strings.ToLower(key)
+	if id, ok := m.Species[key]; ok {
+		return id, true
+	}
+	if dex == 0 {
+		return SpeciesId{}, false
+	}
+	if base {
+		return SpeciesId{Pokemon: dex}, true
+	}
+	for _, temp := range importTempEvolutionSuffixes {
+		if strings.HasSuffix(key, temp.suffix) {
+			return SpeciesId{Pokemon: dex, Evolution: temp.evolution}, true
+		}
+	}
+	return SpeciesId{}, false
+}
+
+// importResolver resolves species through mapping and collects keys which can't be resolved.
+type importResolver struct {
+	mapping    *ImportMapping
+	unresolved map[string]bool
+}
+
+func newImportResolver(mapping *ImportMapping) *importResolver {
+	return &importResolver{mapping: mapping, unresolved: make(map[string]bool)}
+}
+
+// resolve returns resolveSpecies result, recording unresolved key unless it's one of importIgnoredSuffixes.
+func (r *importResolver) resolve(key string, dex int, base bool) (SpeciesId, bool) {
+	id, ok := r.mapping.resolveSpecies(key, dex, base)
+	if !ok {
+		key = strings.ToLower(key)
+		ignored := false
+		for _, suffix := range importIgnoredSuffixes {
+			ignored = ignored || strings.HasSuffix(key, suffix)
+		}
+		if !ignored {
+			r.unresolved[key] = true
+		}
+	}
+	return id, ok
+}
+
+// report returns sorted unresolved keys.
+func (r *importResolver) report() []string {
+	result := make([]string, 0, len(r.unresolved))
+	for key := range r.unresolved {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// importSpecies is holding species data common to importers before it's merged into PokemonData.
+type importSpecies struct {
+	id         SpeciesId
+	name       string
+	stats      PokemonStats
+	types      []int
+	fast       []int
+	charged    []int
+	evolutions []Evolution
+	hasTypes   bool
+}
+
+// buildImportedPokemonData merges imported species into PokemonData, base species first, then forms and temp evolutions.
+func buildImportedPokemonData(species []importSpecies, moves map[int]Move) PokemonData {
+	data := PokemonData{Pokemon: make(map[int]Pokemon), Costumes: make(map[int]bool), Moves: moves}
+	for _, s := range species {
+		if s.id.Form != 0 || s.id.Evolution != 0 {
+			continue
+		}
+		data.Pokemon[s.id.Pokemon] = Pokemon{
+			Name:         s.name,
+			Attack:       s.stats.Attack,
+			Defense:      s.stats.Defense,
+			Stamina:      s.stats.Stamina,
+			Types:        s.types,
+			FastMoves:    s.fast,
+			ChargedMoves: s.charged,
+			Evolutions:   s.evolutions,
+			Forms:        make(map[int]Form),
+		}
+	}
+	for _, s := range species {
+		masterPokemon, ok := data.Pokemon[s.id.Pokemon]
+		if !ok || s.id.Form == 0 || s.id.Evolution != 0 {
+			continue
+		}
+		form := Form{Name: s.name, FastMoves: s.fast, ChargedMoves: s.charged, Evolutions: s.evolutions}
+		if s.stats.Attack != masterPokemon.Attack || s.stats.Defense != masterPokemon.Defense || s.stats.Stamina != masterPokemon.Stamina {
+			form.Attack, form.Defense, form.Stamina = s.stats.Attack, s.stats.Defense, s.stats.Stamina
+		}
+		if s.hasTypes && !equalInts(s.types, masterPokemon.Types) {
+			form.Types = s.types
+		}
+		masterPokemon.Forms[s.id.Form] = form
+	}
+	for _, s := range species {
+		masterPokemon, ok := data.Pokemon[s.id.Pokemon]
+		if !ok || s.id.Evolution == 0 {
+			continue
+		}
+		types := masterPokemon.Types
+		if form, ok := masterPokemon.Forms[s.id.Form]; ok && len(form.Types) != 0 {
+			types = form.Types
+		}
+		if s.hasTypes && !equalInts(s.types, types) {
+			s.stats.Types = s.types
+		}
+		if s.id.Form == 0 {
+			if masterPokemon.TempEvolutions == nil {
+				masterPokemon.TempEvolutions = make(map[int]PokemonStats)
+			}
+			masterPokemon.TempEvolutions[s.id.Evolution] = s.stats
+			data.Pokemon[s.id.Pokemon] = masterPokemon
+		} else if form, ok := masterPokemon.Forms[s.id.Form]; ok {
+			if form.TempEvolutions == nil {
+				form.TempEvolutions = make(map[int]PokemonStats)
+			}
+			form.TempEvolutions[s.id.Evolution] = s.stats
+			masterPokemon.Forms[s.id.Form] = form
+		}
+	}
+	markLittle(&data)
+	data.Initialized = true
+	return data
+}
+
+type pvpokeGameMaster struct {
+	Pokemon []struct {
+		Dex         int    `json:"dex"`
+		SpeciesId   string `json:"speciesId"`
+		SpeciesName string `json:"speciesName"`
+		BaseStats   struct {
+			Atk int `json:"atk"`
+			Def int `json:"def"`
+			Hp  int `json:"hp"`
+		} `json:"baseStats"`
+		Types        []string `json:"types"`
+		FastMoves    []string `json:"fastMoves"`
+		ChargedMoves []string `json:"chargedMoves"`
+		Family       struct {
+			Evolutions []string `json:"evolutions"`
+		} `json:"family"`
+	} `json:"pokemon"`
+	Moves []struct {
+		MoveId     string `json:"moveId"`
+		Type       string `json:"type"`
+		Power      int    `json:"power"`
+		Energy     int    `json:"energy"`
+		EnergyGain int    `json:"energyGain"`
+		Cooldown   int    `json:"cooldown"`
+	} `json:"moves"`
+}
+
+// pvpokeName returns species name of pvpoke name, or form name in parentheses for forms, like "Alolan" for "Meowth (Alolan)".
+func pvpokeName(speciesName string, form int) string {
+	start, end := strings.Index(speciesName, " ("), strings.LastIndex(speciesName, ")")
+	if form == 0 {
+		if start != -1 {
+			return speciesName[:start]
+		}
+		return speciesName
+	}
+	if start == -1 || end < start {
+		return ""
+	}
+	return speciesName[start+2 : end]
+}
+
+// ImportPvPoke Convert pvpoke gamemaster.json into PokemonData, resolving species and moves through mapping.
+// Species without form qualifier in speciesName and megas are resolved from dex when not mapped.
+// Shadow entries and unmapped moves are skipped, other species which can't be resolved are returned as unresolved speciesIds.
+func ImportPvPoke(raw []byte, mapping ImportMapping) (PokemonData, []string, error) {
+	var gameMaster pvpokeGameMaster
+	if err := json.Unmarshal(raw, &gameMaster); err != nil {
+		return PokemonData{}, nil, ErrImportUnmarshall
+	}
+
+	moves := make(map[int]Move)
+	for _, move := range gameMaster.Moves {
+		moveId, ok := mapping.Moves[move.MoveId]
+		if !ok {
+			continue
+		}
+		entry := Move{Name: move.MoveId, Type: typeByName(move.Type), Power: move.Power, Energy: move.Energy}
+		if move.EnergyGain > 0 {
+			entry.Energy = move.EnergyGain
+			entry.Turns = move.Cooldown / 500
+		}
+		moves[moveId] = entry
+	}
+	convertMoves := func(names []string) []int {
+		var result []int
+		for _, name := range names {
+			if moveId, ok := mapping.Moves[name]; ok {
+				result = append(result, moveId)
+			}
+		}
+		return result
+	}
+
+	dexes := make(map[string]int)
+	bases := make(map[string]bool)
+	for _, p := range gameMaster.Pokemon {
+		dexes[p.SpeciesId] = p.Dex
+		bases[p.SpeciesId] = !strings.Contains(p.SpeciesName, "(")
+	}
+
+	resolver := newImportResolver(&mapping)
+	var species []importSpecies
+	for _, p := range gameMaster.Pokemon {
+		id, ok := resolver.resolve(p.SpeciesId, p.Dex, bases[p.SpeciesId])
+		if !ok {
+			continue
+		}
+		s := importSpecies{
+			id:       id,
+			name:     pvpokeName(p.SpeciesName, id.Form),
+			stats:    PokemonStats{Attack: p.BaseStats.Atk, Defense: p.BaseStats.Def, Stamina: p.BaseStats.Hp},
+			types:    typesByName(p.Types),
+			fast:     convertMoves(p.FastMoves),
+			charged:  convertMoves(p.ChargedMoves),
+			hasTypes: true,
+		}
+		for _, target := range p.Family.Evolutions {
+			if targetId, ok := resolver.resolve(target, dexes[target], bases[target]); ok && targetId.Evolution == 0 {
+				s.evolutions = append(s.evolutions, Evolution{Pokemon: targetId.Pokemon, Form: targetId.Form})
+			}
+		}
+		species = append(species, s)
+	}
+	return buildImportedPokemonData(species, moves), resolver.report(), nil
+}
+
+type pogoApiStats struct {
+	PokemonId   int    `json:"pokemon_id"`
+	PokemonName string `json:"pokemon_name"`
+	Form        string `json:"form"`
+	BaseAttack  int    `json:"base_attack"`
+	BaseDefense int    `json:"base_defense"`
+	BaseStamina int    `json:"base_stamina"`
+}
+
+type pogoApiTypes struct {
+	PokemonId   int      `json:"pokemon_id"`
+	PokemonName string   `json:"pokemon_name"`
+	Form        string   `json:"form"`
+	Type        []string `json:"type"`
+}
+
+type pogoApiEvolutions struct {
+	PokemonId   int    `json:"pokemon_id"`
+	PokemonName string `json:"pokemon_name"`
+	Form        string `json:"form"`
+	Evolutions  []struct {
+		PokemonId      int    `json:"pokemon_id"`
+		PokemonName    string `json:"pokemon_name"`
+		Form           string `json:"form"`
+		GenderRequired string `json:"gender_required"`
+		ItemRequired   string `json:"item_required"`
+	} `json:"evolutions"`
+}
+
+type pogoApiMega struct {
+	PokemonId   int      `json:"pokemon_id"`
+	PokemonName string   `json:"pokemon_name"`
+	Form        string   `json:"form"`
+	MegaName    string   `json:"mega_name"`
+	Type        []string `json:"type"`
+	Stats       struct {
+		BaseAttack  int `json:"base_attack"`
+		BaseDefense int `json:"base_defense"`
+		BaseStamina int `json:"base_stamina"`
+	} `json:"stats"`
+}
+
+// pogoApiKey returns species key of pogoapi name and form, "Normal" form is omitted.
+func pogoApiKey(name, form string) string {
+	key := strings.ReplaceAll(strings.ToLower(name), " ", "_")
+	if form != "" && !strings.EqualFold(form, "normal") {
+		key += "_" + strings.ReplaceAll(strings.ToLower(form), " ", "_")
+	}
+	return key
+}
+
+// pogoApiBase returns true for form of base species, "Normal" or empty.
+func pogoApiBase(form string) bool {
+	return form == "" || strings.EqualFold(form, "normal")
+}
+
+// pogoApiName returns species name for base species and form name for forms, "Normal" form is unnamed.
+func pogoApiName(name, formName string, form int) string {
+	if form == 0 {
+		return name
+	}
+	if strings.EqualFold(formName, "normal") {
+		return ""
+	}
+	return formName
+}
+
+// pogoApiTempEvolution returns temp evolution ID of pogoapi mega name like "Mega Charizard X".
+func pogoApiTempEvolution(megaName string) int {
+	switch {
+	case strings.HasPrefix(megaName, "Primal "):
+		return TempEvolutionPrimal
+	case strings.HasSuffix(megaName, " X"):
+		return TempEvolutionMegaX
+	case strings.HasSuffix(megaName, " Y"):
+		return TempEvolutionMegaY
+	}
+	return TempEvolutionMega
+}
+
+// ImportPogoApi Convert pogoapi pokemon_stats.json (required), pokemon_types.json, pokemon_evolutions.json and mega_pokemon.json
+// (nil when not available) into PokemonData, resolving species through mapping.
+// "Normal" forms and megas are resolved from pokemon_id when not mapped.
+// Shadow and purified forms are skipped, other species which can't be resolved are returned as unresolved "<name>_<form>" keys.
+func ImportPogoApi(statsRaw, typesRaw, evolutionsRaw, megaRaw []byte, mapping ImportMapping) (PokemonData, []string, error) {
+	var stats []pogoApiStats
+	var types []pogoApiTypes
+	var evolutions []pogoApiEvolutions
+	var megas []pogoApiMega
+	for _, file := range []struct {
+		raw    []byte
+		target interface{}
+	}{{statsRaw, &stats}, {typesRaw, &types}, {evolutionsRaw, &evolutions}, {megaRaw, &megas}} {
+		if file.raw == nil {
+			continue
+		}
+		if err := json.Unmarshal(file.raw, file.target); err != nil {
+			return PokemonData{}, nil, ErrImportUnmarshall
+		}
+	}
+
+	resolver := newImportResolver(&mapping)
+
+	typesByKey := make(map[string][]int)
+	for _, t := range types {
+		typesByKey[pogoApiKey(t.PokemonName, t.Form)] = typesByName(t.Type)
+	}
+	evolutionsByKey := make(map[string][]Evolution)
+	for _, e := range evolutions {
+		var result []Evolution
+		for _, target := range e.Evolutions {
+			targetId, ok := resolver.resolve(pogoApiKey(target.PokemonName, target.Form), target.PokemonId, pogoApiBase(target.Form))
+			if !ok {
+				continue
+			}
+			evolution := Evolution{Pokemon: targetId.Pokemon, Form: targetId.Form, GenderRequirement: importGenders[strings.ToLower(target.GenderRequired)]}
+			if target.ItemRequired != "" {
+				evolution.Conditions = append(evolution.Conditions, EvolutionCondition{Kind: EvolutionConditionItem, Item: mapping.Items[target.ItemRequired]})
+			}
+			result = append(result, evolution)
+		}
+		evolutionsByKey[pogoApiKey(e.PokemonName, e.Form)] = result
+	}
+
+	var species []importSpecies
+	for _, s := range stats {
+		key := pogoApiKey(s.PokemonName, s.Form)
+		id, ok := resolver.resolve(key, s.PokemonId, pogoApiBase(s.Form))
+		if !ok || id.Evolution != 0 {
+			continue
+		}
+		entry := importSpecies{
+			id:         id,
+			name:       pogoApiName(s.PokemonName, s.Form, id.Form),
+			stats:      PokemonStats{Attack: s.BaseAttack, Defense: s.BaseDefense, Stamina: s.BaseStamina},
+			evolutions: evolutionsByKey[key],
+		}
+		entry.types, entry.hasTypes = typesByKey[key]
+		species = append(species, entry)
+	}
+	for _, m := range megas {
+		id, ok := resolver.resolve(pogoApiKey(m.PokemonName, m.Form), m.PokemonId, pogoApiBase(m.Form))
+		if !ok {
+			continue
+		}
+		id.Evolution = pogoApiTempEvolution(m.MegaName)
+		species = append(species, importSpecies{
+			id:       id,
+			stats:    PokemonStats{Attack: m.Stats.BaseAttack, Defense: m.Stats.BaseDefense, Stamina: m.Stats.BaseStamina},
+			types:    typesByName(m.Type),
+			hasTypes: len(m.Type) != 0,
+		})
+	}
+	return buildImportedPokemonData(species, nil), resolver.report(), nil
+}
+
+// LoadPvPokeData Load pvpoke gamemaster.json from provided filePath, convert it and keep it in memory.
+func (o *Ohbem) LoadPvPokeData(filePath string, mapping ImportMapping) error {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return ErrImportOpen
+	}
+	pokemonData, unresolved, err := ImportPvPoke(raw, mapping)
+	if err != nil {
+		return err
+	}
+	o.logUnresolved(unresolved)
+	o.usePokemonData(pokemonData, MasterFileSourceFile, fileModTime(filePath))
+	return nil
+}
+
+// LoadPogoApiData Load pogoapi files from provided directory, convert them and keep them in memory.
+// Only pokemon_stats.json is required, pokemon_types.json, pokemon_evolutions.json and mega_pokemon.json are used when present.
+func (o *Ohbem) LoadPogoApiData(dirPath string, mapping ImportMapping) error {
+	files := make([][]byte, 4)
+	var updatedAt time.Time
+	for ix, name := range []string{pogoApiStatsFile, pogoApiTypesFile, pogoApiEvolutionsFile, pogoApiMegaFile} {
+		filePath := filepath.Join(dirPath, name)
+		raw, err := os.ReadFile(filePath)
+		if err != nil {
+			if ix == 0 || !os.IsNotExist(err) {
+				return ErrImportOpen
+			}
+			continue
+		}
+		files[ix] = raw
+		if modTime := fileModTime(filePath); modTime.After(updatedAt) {
+			updatedAt = modTime
+		}
+	}
+	pokemonData, unresolved, err := ImportPogoApi(files[0], files[1], files[2], files[3], mapping)
+	if err != nil {
+		return err
+	}
+	o.logUnresolved(unresolved)
+	o.usePokemonData(pokemonData, MasterFileSourceFile, updatedAt)
+	return nil
+}
+
+// logUnresolved logs species skipped by importer because they can't be resolved through ImportMapping.
+func (o *Ohbem) logUnresolved(unresolved []string) {
+	if len(unresolved) != 0 {
+		o.log(fmt.Sprintf("Import skipped %d unresolved species: %s", len(unresolved), strings.Join(unresolved, ", ")))
+	}
+}
@@ -0,0 +1,171 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func loadImportMapping(t *testing.T) ImportMapping {
+	var mapping ImportMapping
+	raw, err := os.ReadFile("./test/import-mapping-test.json")
+	if err != nil {
+		t.Fatalf("can't load import mapping")
+	}
+	if err := json.Unmarshal(raw, &mapping); err != nil {
+		t.Fatalf("can't unmarshal import mapping")
+	}
+	return mapping
+}
+
+func TestImportPvPoke(t *testing.T) {
+	raw, err := os.ReadFile("./test/pvpoke-test.json")
+	if err != nil {
+		t.Fatalf("can't load pvpoke gamemaster")
+	}
+	data, unresolved, err := ImportPvPoke(raw, loadImportMapping(t))
+	if err != nil {
+		t.Fatalf("ImportPvPoke returned error: %s", err)
+	}
+
+	var tests = []struct {
+		got      interface{}
+		expected interface{}
+	}{
+		{len(data.Pokemon), 5},
+		{unresolved, []string{}},
+		{data.Pokemon[1].Types, []int{TypeGrass, TypePoison}},
+		{data.Pokemon[1].FastMoves, []int{214, 221}},
+		{data.Pokemon[1].ChargedMoves, []int{90, 118}},
+		{data.Pokemon[1].Evolutions, []Evolution{{Pokemon: 2}}},
+		{data.Pokemon[1].Little, true},
+		{len(data.Pokemon[1].Forms), 0},
+		{data.Pokemon[3].TempEvolutions, map[int]PokemonStats{1: {Attack: 241, Defense: 246, Stamina: 190}}},
+		{data.Pokemon[52].Types, []int{TypeNormal}},
+		{data.Pokemon[52].Name, "Meowth"},
+		{data.Pokemon[52].Forms[64].Name, "Alolan"},
+		{data.Pokemon[52].Forms[64].Attack, 99},
+		{data.Pokemon[52].Forms[64].Types, []int{TypeDark}},
+		{data.Pokemon[52].Forms[64].Evolutions, []Evolution{{Pokemon: 53, Form: 65}}},
+		{data.Pokemon[53].Forms[65].Attack, 158},
+		{data.Moves[214], Move{Name: "VINE_WHIP", Type: TypeGrass, Power: 5, Energy: 8, Turns: 2}},
+		{data.Moves[90], Move{Name: "SLUDGE_BOMB", Type: TypePoison, Power: 80, Energy: 50}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if !reflect.DeepEqual(test.got, test.expected) {
+				t.Errorf("got %+v, want %+v", test.got, test.expected)
+			}
+		})
+	}
+
+	if _, _, err := ImportPvPoke([]byte("["), ImportMapping{}); err != ErrImportUnmarshall {
+		t.Errorf("expected ErrImportUnmarshall, got %v", err)
+	}
+}
+
+func TestImportUnmappedBaseSpecies(t *testing.T) {
+	pvpoke := []byte(`{"pokemon": [
+		{"dex": 122, "speciesId": "mr_mime", "speciesName": "Mr. Mime", "baseStats": {"atk": 192, "def": 205, "hp": 120}, "types": ["psychic", "fairy"]},
+		{"dex": 785, "speciesId": "tapu_koko", "speciesName": "Tapu Koko", "baseStats": {"atk": 250, "def": 181, "hp": 172}, "types": ["electric", "fairy"]},
+		{"dex": 122, "speciesId": "mr_mime_galarian", "speciesName": "Mr. Mime (Galarian)", "baseStats": {"atk": 183, "def": 169, "hp": 137}, "types": ["ice", "psychic"]},
+		{"dex": 122, "speciesId": "mr_mime_shadow", "speciesName": "Mr. Mime (Shadow)", "baseStats": {"atk": 192, "def": 205, "hp": 120}, "types": ["psychic", "fairy"]}
+	]}`)
+	pvpokeData, pvpokeUnresolved, err := ImportPvPoke(pvpoke, ImportMapping{})
+	if err != nil {
+		t.Fatalf("ImportPvPoke returned error: %s", err)
+	}
+	pogoApi := []byte(`[
+		{"base_attack": 118, "base_defense": 111, "base_stamina": 128, "form": "Normal", "pokemon_id": 1, "pokemon_name": "Bulbasaur"},
+		{"base_attack": 192, "base_defense": 205, "base_stamina": 120, "form": "Normal", "pokemon_id": 122, "pokemon_name": "Mr. Mime"},
+		{"base_attack": 183, "base_defense": 169, "base_stamina": 137, "form": "Galarian", "pokemon_id": 122, "pokemon_name": "Mr. Mime"},
+		{"base_attack": 250, "base_defense": 181, "base_stamina": 172, "form": "Normal", "pokemon_id": 785, "pokemon_name": "Tapu Koko"},
+		{"base_attack": 192, "base_defense": 205, "base_stamina": 120, "form": "Shadow", "pokemon_id": 122, "pokemon_name": "Mr. Mime"}
+	]`)
+	pogoApiData, pogoApiUnresolved, err := ImportPogoApi(pogoApi, nil, nil, nil, ImportMapping{})
+	if err != nil {
+		t.Fatalf("ImportPogoApi returned error: %s", err)
+	}
+
+	var tests = []struct {
+		got      interface{}
+		expected interface{}
+	}{
+		{len(pvpokeData.Pokemon), 2},
+		{pvpokeData.Pokemon[122].Attack, 192},
+		{pvpokeData.Pokemon[785].Name, "Tapu Koko"},
+		{pvpokeUnresolved, []string{"mr_mime_galarian"}},
+		{len(pogoApiData.Pokemon), 3},
+		{pogoApiData.Pokemon[122].Attack, 192},
+		{pogoApiData.Pokemon[785].Name, "Tapu Koko"},
+		{pogoApiUnresolved, []string{"mr._mime_galarian"}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if !reflect.DeepEqual(test.got, test.expected) {
+				t.Errorf("got %+v, want %+v", test.got, test.expected)
+			}
+		})
+	}
+}
+
+func TestLoadPogoApiData(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadPogoApiData("./test/pogoapi", loadImportMapping(t)); err != nil {
+		t.Fatalf("LoadPogoApiData returned error: %s", err)
+	}
+	data := ohbem.PokemonData
+
+	var tests = []struct {
+		got      interface{}
+		expected interface{}
+	}{
+		{len(data.Pokemon), 8},
+		{data.Pokemon[52].Name, "Meowth"},
+		{data.Pokemon[52].Forms[64].Name, "Alola"},
+		{data.Pokemon[1].Types, []int{TypeGrass, TypePoison}},
+		{data.Pokemon[1].Evolutions, []Evolution{{Pokemon: 2}}},
+		{data.Pokemon[1].Little, true},
+		{len(data.Pokemon[1].Forms), 0},
+		{data.Pokemon[3].TempEvolutions, map[int]PokemonStats{1: {Attack: 241, Defense: 246, Stamina: 190}}},
+		{data.Pokemon[52].Forms[64].Attack, 99},
+		{data.Pokemon[52].Forms[64].Types, []int{TypeDark}},
+		{data.Pokemon[52].Forms[64].Evolutions, []Evolution{{Pokemon: 53, Form: 65}}},
+		{data.Pokemon[361].Evolutions, []Evolution{
+			{Pokemon: 362},
+			{Pokemon: 478, GenderRequirement: 2, Conditions: []EvolutionCondition{{Kind: EvolutionConditionItem, Item: 1106}}},
+		}},
+		{ohbem.MasterFileStatus().Source, MasterFileSourceFile},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if !reflect.DeepEqual(test.got, test.expected) {
+				t.Errorf("got %+v, want %+v", test.got, test.expected)
+			}
+		})
+	}
+
+	entries, err := ohbem.QueryPvPRank(52, 64, 0, 1, 0, 15, 15, 1)
+	if err != nil {
+		t.Errorf("QueryPvPRank returned error: %s", err)
+	}
+	found := false
+	for _, entry := range entries["great"] {
+		found = found || entry.Pokemon == 53 && entry.Form == 65
+	}
+	if !found {
+		t.Errorf("Alolan Meowth should evolve into Alolan Persian")
+	}
+
+	if err := ohbem.LoadPogoApiData("./test/missing", ImportMapping{}); err != ErrImportOpen {
+		t.Errorf("expected ErrImportOpen, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+// Command genmasterfile refreshes MasterFile snapshot embedded by gohbem.
+// It's run by go generate from repository root:
+//
+//	go generate ./...
+//
+// MasterFile is fetched from -url (same address as gohbem.MasterFileURL by default), or read from -in when provided,
+// validated, compacted and written gzip compressed to -out, with fetch time stored in gzip header.
+// It doesn't import gohbem, so it runs even when embedded snapshot is missing or broken.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// masterFileURL is default MasterFile address, kept in sync with gohbem.MasterFileURL.
+const masterFileURL = "https://raw.githubusercontent.com/WatWowMap/Masterfile-Generator/master/master-latest-basics.json"
+
+// masterFile is part of MasterFile needed for validation.
+type masterFile struct {
+	Pokemon map[string]struct {
+		Attack  int `json:"attack"`
+		Defense int `json:"defense"`
+		Stamina int `json:"stamina"`
+	} `json:"pokemon"`
+}
+
+func main() {
+	url := flag.String("url", masterFileURL, "remote MasterFile address")
+	in := flag.String("in", "", "local MasterFile used instead of remote one")
+	out := flag.String("out", "masterfile.json.gz", "compressed MasterFile output path")
+	flag.Parse()
+
+	raw, fetchedAt, err := readMasterFile(*url, *in)
+	if err != nil {
+		log.Fatalf("reading MasterFile: %s", err)
+	}
+	var data masterFile
+	if err := json.Unmarshal(raw, &data); err != nil {
+		log.Fatalf("decoding MasterFile: %s", err)
+	}
+	withStats := 0
+	for _, pokemon := range data.Pokemon {
+		if pokemon.Attack != 0 && pokemon.Defense != 0 && pokemon.Stamina != 0 {
+			withStats++
+		}
+	}
+	if withStats == 0 {
+		log.Fatalf("MasterFile has no Pokemon with stats")
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		log.Fatalf("compacting MasterFile: %s", err)
+	}
+	var compressed bytes.Buffer
+	writer, _ := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	writer.Name = "masterfile.json"
+	writer.ModTime = fetchedAt
+	if _, err := writer.Write(compact.Bytes()); err != nil {
+		log.Fatalf("compressing MasterFile: %s", err)
+	}
+	if err := writer.Close(); err != nil {
+		log.Fatalf("compressing MasterFile: %s", err)
+	}
+
+	tmp := filepath.Join(filepath.Dir(*out), "."+filepath.Base(*out)+".tmp")
+	if err := os.WriteFile(tmp, compressed.Bytes(), 0644); err != nil {
+		log.Fatalf("writing %s: %s", tmp, err)
+	}
+	if err := os.Rename(tmp, *out); err != nil {
+		log.Fatalf("writing %s: %s", *out, err)
+	}
+	fmt.Printf("Wrote %s: %d Pokemon, %d bytes\n", *out, len(data.Pokemon), compressed.Len())
+}
+
+// readMasterFile returns MasterFile read from in, or fetched from url when in is empty, with its update time.
+func readMasterFile(url, in string) ([]byte, time.Time, error) {
+	if in != "" {
+		info, err := os.Stat(in)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		raw, err := os.ReadFile(in)
+		return raw, info.ModTime(), err
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("User-Agent", "Gohbem-genmasterfile")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	return raw, time.Now(), err
+}
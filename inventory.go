@@ -0,0 +1,157 @@
+package gohbem
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// inventoryTarget identifies evolution target competing for the best candidate in league.
+type inventoryTarget struct {
+	league    string
+	pokemon   int
+	form      int
+	evolution int
+	cap       float64
+}
+
+// RankInventory Rank whole collection in parallel, flagging the best OwnedPokemon per evolution target, league and level cap.
+// Only entries ranked at or better than maxRank are considered, workers <= 0 uses one worker per CPU.
+// Pokemon being best candidate at least once are kept, sorted by their best rank; the rest is transferred in input order.
+// Shadow Pokemon which can't be resolved to shadow form fail with ErrInventoryShadowForm, so they don't compete as their regular form.
+func (o *Ohbem) RankInventory(inventory []OwnedPokemon, maxRank int16, workers int) (InventoryReport, error) {
+	var report InventoryReport
+
+	if err := safetyCheck(o); err != nil {
+		return report, err
+	}
+	if maxRank < 1 {
+		return report, ErrQueryInputOutOfRange
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	results := make([]InventoryResult, len(inventory))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ix := range jobs {
+				owned := inventory[ix]
+				form, err := o.inventoryForm(&owned)
+				var entries map[string][]PokemonEntry
+				if err == nil {
+					entries, err = o.QueryPvPRank(owned.Pokemon, form, owned.Costume, owned.Gender, owned.Attack, owned.Defense, owned.Stamina, owned.Level)
+				}
+				results[ix] = InventoryResult{Index: ix, Owned: owned, Err: err}
+				if err == nil && len(entries) != 0 {
+					results[ix].Entries = entries
+				}
+			}
+		}()
+	}
+	for ix := range inventory {
+		jobs <- ix
+	}
+	close(jobs)
+	wg.Wait()
+
+	// pick best candidate per target, ties are won by lucky Pokemon and then by input order
+	best := make(map[inventoryTarget]int)
+	for ix := range results {
+		for leagueName, entries := range results[ix].Entries {
+			for _, entry := range entries {
+				if entry.Rank < 1 || entry.Rank > maxRank {
+					continue
+				}
+				target := inventoryTarget{leagueName, entry.Pokemon, entry.Form, entry.Evolution, entry.Cap}
+				current, ok := best[target]
+				if !ok || entry.Rank < bestRank(&results[current], target) ||
+					entry.Rank == bestRank(&results[current], target) && results[ix].Owned.Lucky && !results[current].Owned.Lucky {
+					best[target] = ix
+				}
+			}
+		}
+	}
+	for target, ix := range best {
+		for _, entry := range results[ix].Entries[target.league] {
+			if entry.Pokemon == target.pokemon && entry.Form == target.form && entry.Evolution == target.evolution && entry.Cap == target.cap {
+				results[ix].Best = append(results[ix].Best, InventoryPick{League: target.league, PokemonEntry: entry})
+			}
+		}
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			report.Failed = append(report.Failed, result)
+		case len(result.Best) != 0:
+			sort.Slice(result.Best, func(i, j int) bool {
+				a, b := &result.Best[i], &result.Best[j]
+				if a.Rank != b.Rank {
+					return a.Rank < b.Rank
+				}
+				if a.League != b.League {
+					return a.League < b.League
+				}
+				if a.Pokemon != b.Pokemon {
+					return a.Pokemon < b.Pokemon
+				}
+				if a.Evolution != b.Evolution {
+					return a.Evolution < b.Evolution
+				}
+				return a.Cap < b.Cap
+			})
+			report.Keep = append(report.Keep, result)
+		default:
+			report.Transfer = append(report.Transfer, result)
+		}
+	}
+	sort.SliceStable(report.Keep, func(i, j int) bool {
+		return report.Keep[i].Best[0].Rank < report.Keep[j].Best[0].Rank
+	})
+	return report, nil
+}
+
+// bestRank returns rank of InventoryResult entry matching target.
+func bestRank(result *InventoryResult, target inventoryTarget) int16 {
+	for _, entry := range result.Entries[target.league] {
+		if entry.Pokemon == target.pokemon && entry.Form == target.form && entry.Evolution == target.evolution && entry.Cap == target.cap {
+			return entry.Rank
+		}
+	}
+	return 0
+}
+
+// inventoryForm returns form OwnedPokemon is ranked as, shadow Pokemon are mapped to the only shadow form of their species
+// when they carry form 0 or form without own stats.
+func (o *Ohbem) inventoryForm(owned *OwnedPokemon) (int, error) {
+	if !owned.Shadow {
+		return owned.Form, nil
+	}
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	masterPokemon := o.PokemonData.Pokemon[owned.Pokemon]
+	masterForm, ok := masterPokemon.Forms[owned.Form]
+	if ok && masterForm.Shadow {
+		return owned.Form, nil
+	}
+	if owned.Form != 0 && (!ok || masterForm.Attack != 0) {
+		return 0, ErrInventoryShadowForm
+	}
+	shadowForm := 0
+	for formId, candidate := range masterPokemon.Forms {
+		if candidate.Shadow {
+			if shadowForm != 0 {
+				return 0, ErrInventoryShadowForm
+			}
+			shadowForm = formId
+		}
+	}
+	if shadowForm == 0 {
+		return 0, ErrInventoryShadowForm
+	}
+	return shadowForm, nil
+}
@@ -0,0 +1,147 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRankInventory(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	inventory := []OwnedPokemon{
+		{Pokemon: 661, Attack: 15, Defense: 15, Stamina: 15, Level: 1},
+		{Pokemon: 661, Attack: 0, Defense: 14, Stamina: 15, Level: 1},
+		{Pokemon: 662, Attack: 0, Defense: 14, Stamina: 15, Level: 1, Lucky: true},
+		{Pokemon: 100000, Attack: 0, Defense: 14, Stamina: 15, Level: 1},
+		{Pokemon: 663, Attack: 15, Defense: 0, Stamina: 0, Level: 40},
+	}
+
+	report, err := ohbem.RankInventory(inventory, 100, 4)
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Index != 3 || report.Failed[0].Err != ErrMissingPokemon {
+		t.Errorf("got %+v, want index 3 failed with %v", report.Failed, ErrMissingPokemon)
+	}
+	if len(report.Keep)+len(report.Transfer)+len(report.Failed) != len(inventory) {
+		t.Errorf("got %d results, want %d", len(report.Keep)+len(report.Transfer)+len(report.Failed), len(inventory))
+	}
+
+	kept := make(map[int]bool)
+	for ex, result := range report.Keep {
+		kept[result.Index] = true
+		if ex > 0 && report.Keep[ex-1].Best[0].Rank > result.Best[0].Rank {
+			t.Errorf("got rank %d after %d, want ascending", result.Best[0].Rank, report.Keep[ex-1].Best[0].Rank)
+		}
+		for _, pick := range result.Best {
+			if pick.Rank > 100 {
+				t.Errorf("got %+v, want rank at most 100", pick)
+			}
+		}
+	}
+	if !kept[2] {
+		t.Errorf("got %v, want lucky index 2 kept", kept)
+	}
+	for _, result := range report.Keep {
+		if result.Index != 1 {
+			continue
+		}
+		for _, pick := range result.Best {
+			if pick.Pokemon != 661 {
+				t.Errorf("got %d, want %d", pick.Pokemon, 661)
+			}
+		}
+	}
+	for _, result := range report.Transfer {
+		if len(result.Best) != 0 {
+			t.Errorf("%d: got %+v, want no picks", result.Index, result.Best)
+		}
+	}
+
+	if _, err := ohbem.RankInventory(inventory, 0, 1); err != ErrQueryInputOutOfRange {
+		t.Errorf("got %v, want %v", err, ErrQueryInputOutOfRange)
+	}
+}
+
+func TestRankInventoryShadow(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var tests = []struct {
+		owned OwnedPokemon
+		err   error
+	}{
+		{OwnedPokemon{Pokemon: 1, Attack: 0, Defense: 15, Stamina: 15, Level: 1, Shadow: true}, nil},
+		{OwnedPokemon{Pokemon: 1, Form: 163, Attack: 0, Defense: 15, Stamina: 15, Level: 1, Shadow: true}, nil},
+		{OwnedPokemon{Pokemon: 1, Form: 164, Attack: 0, Defense: 15, Stamina: 15, Level: 1, Shadow: true}, nil},
+		{OwnedPokemon{Pokemon: 1, Form: 99999, Attack: 0, Defense: 15, Stamina: 15, Level: 1, Shadow: true}, ErrInventoryShadowForm},
+		{OwnedPokemon{Pokemon: 4, Attack: 0, Defense: 15, Stamina: 15, Level: 1, Shadow: true}, ErrInventoryShadowForm},
+		{OwnedPokemon{Pokemon: 52, Form: 64, Attack: 0, Defense: 15, Stamina: 15, Level: 1, Shadow: true}, ErrInventoryShadowForm},
+		{OwnedPokemon{Pokemon: 1, Attack: 0, Defense: 15, Stamina: 15, Level: 1}, nil},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			report, err := ohbem.RankInventory([]OwnedPokemon{test.owned}, 4096, 1)
+			if err != nil {
+				t.Errorf("got %v, want nil", err)
+			}
+			var got error
+			if len(report.Failed) != 0 {
+				got = report.Failed[0].Err
+			}
+			if got != test.err {
+				t.Errorf("got %v, want %v", got, test.err)
+			}
+			for _, result := range append(report.Keep, report.Transfer...) {
+				for _, entries := range result.Entries {
+					for _, entry := range entries {
+						if test.owned.Shadow && entry.Pokemon == 1 && entry.Form != 164 {
+							t.Errorf("got %+v, want shadow form", entry)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRankInventoryComparator(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	if err := ohbem.UseRankingComparatorPreset("prefer_bulk"); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+
+	// workers share Ohbem, run with -race to catch writes during ranking
+	var inventory []OwnedPokemon
+	for _, pokemonId := range []int{1, 2, 3, 661, 662, 663} {
+		inventory = append(inventory, OwnedPokemon{Pokemon: pokemonId, Attack: 1, Defense: 15, Stamina: 15, Level: 1})
+	}
+	report, err := ohbem.RankInventory(inventory, 4096, 4)
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	for _, result := range append(report.Keep, report.Transfer...) {
+		expected, _ := ohbem.QueryPvPRank(result.Owned.Pokemon, 0, 0, 0, 1, 15, 15, 1)
+		sortEntries(result.Entries)
+		sortEntries(expected)
+		if fmt.Sprint(result.Entries) != fmt.Sprint(expected) {
+			t.Errorf("%d: got %+v, want %+v", result.Index, result.Entries, expected)
+		}
+	}
+	if len(report.Keep)+len(report.Transfer) != len(inventory) {
+		t.Errorf("got %d results, want %d", len(report.Keep)+len(report.Transfer), len(inventory))
+	}
+}
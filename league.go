@@ -0,0 +1,78 @@
+package gohbem
+
+// LegacyUncappedLeague is League name ranked as uncapped whatever its Cap, as it was before League.Uncapped existed.
+const LegacyUncappedLeague = "master"
+
+// namedLeague returns League configured under name, marking legacy LegacyUncappedLeague as Uncapped.
+func namedLeague(name string, league League) League {
+	if name == LegacyUncappedLeague {
+		league.Uncapped = true
+	}
+	return league
+}
+
+// IsUncapped reports whether League has no CP cap and should be ranked as functionally perfect.
+func (l League) IsUncapped() bool {
+	return l.Uncapped || l.Cap <= 0
+}
+
+// levelCaps returns League specific level caps, falling back to provided defaults.
+func (l League) levelCaps(defaults []int) []int {
+	if len(l.LevelCaps) != 0 {
+		return l.LevelCaps
+	}
+	return defaults
+}
+
+// hasIvFloor reports whether provided IVs are all at or above League IvFloor.
+func (l League) hasIvFloor(attack, defense, stamina int) bool {
+	return attack >= l.IvFloor && defense >= l.IvFloor && stamina >= l.IvFloor
+}
+
+// IsEligible reports whether Pokemon with provided form and types can enter League according to its allow/ban lists.
+// Pokemon without types (MasterFile without types) can't enter League with type lists, see eligibility.
+func (l League) IsEligible(pokemonId, form int, types []int) bool {
+	return l.eligibility(pokemonId, form, types) == ""
+}
+
+// hasTypeLists reports whether League restricts types.
+func (l League) hasTypeLists() bool {
+	return len(l.AllowedTypes) != 0 || len(l.BannedTypes) != 0
+}
+
+// eligibility returns reason why Pokemon with provided form and types can't enter League, "" when it can.
+// Types of Pokemon without them are unknown, so they're excluded from League with type lists as DecisionTypesUnknown.
+func (l League) eligibility(pokemonId, form int, types []int) DecisionReason {
+	if matchLeaguePokemon(l.BannedPokemon, pokemonId, form) {
+		return DecisionLeagueRestricted
+	}
+	if len(l.AllowedPokemon) != 0 && !matchLeaguePokemon(l.AllowedPokemon, pokemonId, form) {
+		return DecisionLeagueRestricted
+	}
+	if len(types) == 0 && l.hasTypeLists() {
+		return DecisionTypesUnknown
+	}
+	for _, t := range types {
+		if containsInt(l.BannedTypes, t) {
+			return DecisionLeagueRestricted
+		}
+	}
+	if len(l.AllowedTypes) != 0 {
+		for _, t := range types {
+			if containsInt(l.AllowedTypes, t) {
+				return ""
+			}
+		}
+		return DecisionLeagueRestricted
+	}
+	return ""
+}
+
+func matchLeaguePokemon(list []LeaguePokemon, pokemonId, form int) bool {
+	for _, entry := range list {
+		if entry.Pokemon == pokemonId && (entry.Form == 0 || entry.Form == form) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package gohbem
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestLeagueIsEligible(t *testing.T) {
+	var tests = []struct {
+		league    League
+		pokemonId int
+		form      int
+		types     []int
+		output    bool
+	}{
+		{League{}, 25, 0, []int{TypeElectric}, true},
+		{League{BannedPokemon: []LeaguePokemon{{Pokemon: 25}}}, 25, 2, []int{TypeElectric}, false},
+		{League{BannedPokemon: []LeaguePokemon{{Pokemon: 25, Form: 3}}}, 25, 2, []int{TypeElectric}, true},
+		{League{AllowedPokemon: []LeaguePokemon{{Pokemon: 26}}}, 25, 0, []int{TypeElectric}, false},
+		{League{AllowedPokemon: []LeaguePokemon{{Pokemon: 25, Form: 2}}}, 25, 2, nil, true},
+		{League{AllowedTypes: []int{TypeWater, TypeElectric}}, 25, 0, []int{TypeElectric}, true},
+		{League{AllowedTypes: []int{TypeWater}}, 25, 0, []int{TypeElectric}, false},
+		{League{AllowedTypes: []int{TypeWater}}, 25, 0, nil, false},
+		{League{BannedTypes: []int{TypeWater}}, 25, 0, nil, false},
+		{League{BannedPokemon: []LeaguePokemon{{Pokemon: 26}}}, 25, 0, nil, true},
+		{League{BannedTypes: []int{TypeFlying}}, 6, 0, []int{TypeFire, TypeFlying}, false},
+		{League{AllowedTypes: []int{TypeFire}, BannedTypes: []int{TypeFlying}}, 6, 0, []int{TypeFire, TypeFlying}, false},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			output := test.league.IsEligible(test.pokemonId, test.form, test.types)
+			if output != test.output {
+				t.Errorf("got %t, want %t", output, test.output)
+			}
+		})
+	}
+}
+
+func TestQueryPvPRankCustomCup(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"no_talonflame": {Cap: 1500, BannedPokemon: []LeaguePokemon{{Pokemon: 663}}},
+		"fire_cup":      {Cap: 1500, AllowedTypes: []int{TypeFire}},
+		"normal_cup":    {Cap: 500, AllowedTypes: []int{TypeNormal}},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	// Fletchling line: 661 normal/flying, 662 and 663 fire/flying
+	for pokemonId, types := range map[int][]int{661: {TypeNormal, TypeFlying}, 662: {TypeFire, TypeFlying}, 663: {TypeFire, TypeFlying}} {
+		pokemon := ohbem.PokemonData.Pokemon[pokemonId]
+		pokemon.Types = types
+		ohbem.PokemonData.Pokemon[pokemonId] = pokemon
+	}
+
+	entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, 15, 15, 14, 1)
+
+	var tests = []struct {
+		league  string
+		allowed []int
+	}{
+		{"no_talonflame", []int{661, 662}},
+		{"fire_cup", []int{662, 663}},
+		{"normal_cup", []int{661}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.league, func(t *testing.T) {
+			if len(entries[test.league]) == 0 {
+				t.Errorf("missing %s in entries", test.league)
+			}
+			for _, entry := range entries[test.league] {
+				if !containsInt(test.allowed, entry.Pokemon) {
+					t.Errorf("got ineligible %+v", entry)
+				}
+			}
+		})
+	}
+}
+
+func TestExplainPvPRankUnknownTypes(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"fire_cup": {Cap: 1500, AllowedTypes: []int{TypeFire}},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	entries, decisions, err := ohbem.ExplainPvPRank(661, 0, 0, 1, 15, 15, 14, 1)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("got %v %+v, want no entries", err, entries)
+	}
+	want := QueryDecision{Pokemon: 663, League: "fire_cup", Reason: DecisionTypesUnknown}
+	for _, decision := range decisions {
+		if reflect.DeepEqual(decision, want) {
+			return
+		}
+	}
+	t.Errorf("decisions are missing %+v", want)
+}
+
+func TestQueryPvPRankLeagueOverrides(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"great":       {Cap: 1500},
+		"great_40":    {Cap: 1500, LevelCaps: []int{40}},
+		"great_floor": {Cap: 1500, IvFloor: 10},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	entries, _ := ohbem.QueryPvPRank(605, 0, 0, 1, 1, 4, 12, 7)
+	for _, entry := range entries["great_40"] {
+		if entry.Cap != 40 {
+			t.Errorf("got %+v, want cap 40", entry)
+		}
+	}
+	if len(entries["great_40"]) == 0 || len(entries["great"]) == 0 {
+		t.Errorf("missing great leagues in entries")
+	}
+	if entries["great_floor"] != nil {
+		t.Errorf("got %+v, want no entries below IV floor", entries["great_floor"])
+	}
+
+	entries, _ = ohbem.QueryPvPRank(605, 0, 0, 1, 10, 15, 15, 7)
+	great, floor := entries["great"][0], entries["great_floor"][0]
+	if great.Value != floor.Value || great.Rank <= floor.Rank || great.Percentage > floor.Percentage {
+		t.Errorf("got %+v and %+v, want better rank within IV floor", great, floor)
+	}
+}
+
+func TestQueryPvPRankTempEvolutionTypes(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"flying_cup": {Cap: 2500, AllowedTypes: []int{TypeFlying}},
+		"dragon_cup": {Cap: 2500, AllowedTypes: []int{TypeDragon}},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	// Charizard is fire/flying, Mega Charizard X is fire/dragon
+	charizard := ohbem.PokemonData.Pokemon[6]
+	charizard.Types = []int{TypeFire, TypeFlying}
+	megaX := charizard.TempEvolutions[TempEvolutionMegaX]
+	megaX.Types = []int{TypeFire, TypeDragon}
+	charizard.TempEvolutions[TempEvolutionMegaX] = megaX
+	ohbem.PokemonData.Pokemon[6] = charizard
+
+	entries, _ := ohbem.QueryPvPRank(6, 0, 0, 1, 0, 15, 15, 1)
+
+	var tests = []struct {
+		league     string
+		evolutions []int
+	}{
+		{"flying_cup", []int{0, TempEvolutionMegaY}},
+		{"dragon_cup", []int{TempEvolutionMegaX}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			var evolutions []int
+			for _, entry := range entries[test.league] {
+				if !containsInt(evolutions, entry.Evolution) {
+					evolutions = append(evolutions, entry.Evolution)
+				}
+			}
+			if !reflect.DeepEqual(evolutions, test.evolutions) {
+				t.Errorf("got %v, want %v", evolutions, test.evolutions)
+			}
+		})
+	}
+}
+
+func TestQueryPvPRankLegacyMasterLeague(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"master":   {Cap: 10000},
+		"uncapped": {Uncapped: true},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, 15, 15, 14, 1)
+	if len(entries["master"]) == 0 || !reflect.DeepEqual(entries["master"], entries["uncapped"]) {
+		t.Errorf("got %+v, want %+v", entries["master"], entries["uncapped"])
+	}
+}
@@ -0,0 +1,210 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// defaultLocale is used when Locale is not provided.
+const defaultLocale = "en"
+
+// defaultEvolutionNames are temp evolution name formats used when locale doesn't provide them.
+var defaultEvolutionNames = map[int]string{
+	TempEvolutionMega:   "Mega %s",
+	TempEvolutionMegaX:  "Mega %s X",
+	TempEvolutionMegaY:  "Mega %s Y",
+	TempEvolutionPrimal: "Primal %s",
+}
+
+// LoadNames Load names of locale from provided filePath, see NameData for its format.
+func (o *Ohbem) LoadNames(locale string, filePath string) error {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return ErrNamesOpen
+	}
+	var names NameData
+	if err := json.Unmarshal(raw, &names); err != nil {
+		return ErrNamesUnmarshall
+	}
+	if o.Names == nil {
+		o.Names = make(map[string]NameData)
+	}
+	o.Names[locale] = names
+	return nil
+}
+
+func (o *Ohbem) locale() string {
+	if o.Locale == "" {
+		return defaultLocale
+	}
+	return o.Locale
+}
+
+// PokemonName returns name of Pokémon form and temp evolution in Locale, like "Galarian Stunfisk" or "Mega Venusaur".
+func (o *Ohbem) PokemonName(pokemonId int, form int, evolution int) string {
+	return o.LocalizedPokemonName(o.locale(), pokemonId, form, evolution)
+}
+
+// LocalizedPokemonName returns name of Pokémon form and temp evolution in provided locale.
+// Names from PokemonData (MasterFile, game master or imported data) are used when locale doesn't have them, unnamed forms are omitted and unknown species are returned as "#<id>".
+func (o *Ohbem) LocalizedPokemonName(locale string, pokemonId int, form int, evolution int) string {
+	names := o.Names[locale]
+	masterPokemon := o.PokemonData.Pokemon[pokemonId]
+
+	name, ok := names.Pokemon[pokemonId]
+	if !ok {
+		name = masterPokemon.Name
+	}
+	if name == "" {
+		name = fmt.Sprintf("#%d", pokemonId)
+	}
+	if form != 0 {
+		formName, ok := names.Forms[form]
+		if !ok {
+			formName = masterPokemon.Forms[form].Name
+		}
+		if formName != "" {
+			name = formName + " " + name
+		}
+	}
+	if evolution != 0 {
+		format, ok := names.Evolutions[evolution]
+		if !ok {
+			format, ok = defaultEvolutionNames[evolution]
+		}
+		if ok {
+			name = fmt.Sprintf(format, name)
+		}
+	}
+	return name
+}
+
+// CostumeName returns name of costume in Locale, "" when unknown.
+func (o *Ohbem) CostumeName(costume int) string {
+	return o.Names[o.locale()].Costumes[costume]
+}
+
+// normalizeName lowercases name and sorts its words, so "Stunfisk (Galarian)" matches "galarian stunfisk".
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// levenshtein returns edit distance of two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// nameMatch is candidate of fuzzy name resolution.
+type nameMatch struct {
+	distance int
+	id       SpeciesId
+}
+
+// better returns true when match should be preferred: closer, then base species over forms and temp evolutions, then lower IDs.
+func (m nameMatch) better(other nameMatch) bool {
+	if m.distance != other.distance {
+		return m.distance < other.distance
+	}
+	if base, otherBase := m.id.Form == 0 && m.id.Evolution == 0, other.id.Form == 0 && other.id.Evolution == 0; base != otherBase {
+		return base
+	}
+	if m.id.Pokemon != other.id.Pokemon {
+		return m.id.Pokemon < other.id.Pokemon
+	}
+	if m.id.Form != other.id.Form {
+		return m.id.Form < other.id.Form
+	}
+	return m.id.Evolution < other.id.Evolution
+}
+
+// maxNameDistance returns highest edit distance accepted for normalized name.
+func maxNameDistance(name string) int {
+	return len([]rune(name)) / 4
+}
+
+// ResolvePokemon Resolve Pokémon name in any loaded locale (like "Galarian Stunfisk" or "Mega Venusaur") to IDs.
+// Small typos are tolerated, the closest name wins.
+func (o *Ohbem) ResolvePokemon(name string) (SpeciesId, error) {
+	query := normalizeName(name)
+	if query == "" {
+		return SpeciesId{}, ErrNameNotFound
+	}
+	locales := []string{o.locale()}
+	for locale := range o.Names {
+		if locale != o.locale() {
+			locales = append(locales, locale)
+		}
+	}
+
+	best := nameMatch{distance: maxNameDistance(query) + 1}
+	consider := func(id SpeciesId) {
+		for _, locale := range locales {
+			distance := levenshtein(query, normalizeName(o.LocalizedPokemonName(locale, id.Pokemon, id.Form, id.Evolution)))
+			if match := (nameMatch{distance, id}); match.better(best) {
+				best = match
+			}
+		}
+	}
+	for pokemonId, masterPokemon := range o.PokemonData.Pokemon {
+		consider(SpeciesId{Pokemon: pokemonId})
+		for evolution := range masterPokemon.TempEvolutions {
+			consider(SpeciesId{Pokemon: pokemonId, Evolution: evolution})
+		}
+		for formId, masterForm := range masterPokemon.Forms {
+			if formId == 0 {
+				continue
+			}
+			consider(SpeciesId{Pokemon: pokemonId, Form: formId})
+			for evolution := range masterForm.TempEvolutions {
+				consider(SpeciesId{Pokemon: pokemonId, Form: formId, Evolution: evolution})
+			}
+		}
+	}
+	if best.id.Pokemon == 0 {
+		return SpeciesId{}, ErrNameNotFound
+	}
+	return best.id, nil
+}
+
+// ResolveCostume Resolve costume name in any loaded locale to its ID, tolerating small typos.
+func (o *Ohbem) ResolveCostume(name string) (int, error) {
+	query := normalizeName(name)
+	bestId, bestDistance := 0, maxNameDistance(query)+1
+	for _, names := range o.Names {
+		for costumeId, costumeName := range names.Costumes {
+			distance := levenshtein(query, normalizeName(costumeName))
+			if distance < bestDistance || distance == bestDistance && costumeId < bestId {
+				bestId, bestDistance = costumeId, distance
+			}
+		}
+	}
+	if bestId == 0 {
+		return 0, ErrNameNotFound
+	}
+	return bestId, nil
+}
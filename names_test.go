@@ -0,0 +1,151 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestResolvePokemon(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadPokemonData("./test/master-test.json"); err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	if err := ohbem.LoadNames("en", "./test/names-en-test.json"); err != nil {
+		t.Errorf("can't load names: %s", err)
+	}
+	if err := ohbem.LoadNames("de", "./test/names-de-test.json"); err != nil {
+		t.Errorf("can't load names: %s", err)
+	}
+
+	var tests = []struct {
+		name     string
+		expected SpeciesId
+		err      error
+	}{
+		{"Galarian Stunfisk", SpeciesId{Pokemon: 618, Form: 2345}, nil},
+		{"stunfisk (galarian)", SpeciesId{Pokemon: 618, Form: 2345}, nil},
+		{"Stunfisk", SpeciesId{Pokemon: 618}, nil},
+		{"Mega Venusaur", SpeciesId{Pokemon: 3, Evolution: 1}, nil},
+		{"Mega Charizard Y", SpeciesId{Pokemon: 6, Evolution: 3}, nil},
+		{"Talonflme", SpeciesId{Pokemon: 663}, nil},
+		{"Galar-Flunschlik", SpeciesId{Pokemon: 618, Form: 2345}, nil},
+		{"Mega-Bisaflor", SpeciesId{Pokemon: 3, Evolution: 1}, nil},
+		{"Pikachu", SpeciesId{}, ErrNameNotFound},
+		{"", SpeciesId{}, ErrNameNotFound},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			id, err := ohbem.ResolvePokemon(test.name)
+			if err != test.err {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+			if id != test.expected {
+				t.Errorf("got %+v, want %+v", id, test.expected)
+			}
+		})
+	}
+}
+
+func TestNameMatchBetter(t *testing.T) {
+	var tests = []struct {
+		match    nameMatch
+		other    nameMatch
+		expected bool
+	}{
+		{nameMatch{distance: 0, id: SpeciesId{Pokemon: 700}}, nameMatch{distance: 1, id: SpeciesId{Pokemon: 1}}, true},
+		{nameMatch{distance: 0, id: SpeciesId{Pokemon: 700}}, nameMatch{distance: 0, id: SpeciesId{Pokemon: 618, Form: 2345}}, true},
+		{nameMatch{distance: 0, id: SpeciesId{Pokemon: 6}}, nameMatch{distance: 0, id: SpeciesId{Pokemon: 3, Evolution: 1}}, true},
+		{nameMatch{distance: 0, id: SpeciesId{Pokemon: 618, Form: 2345}}, nameMatch{distance: 0, id: SpeciesId{Pokemon: 700}}, false},
+		{nameMatch{distance: 0, id: SpeciesId{Pokemon: 3}}, nameMatch{distance: 0, id: SpeciesId{Pokemon: 6}}, true},
+		{nameMatch{distance: 0, id: SpeciesId{Pokemon: 6, Evolution: 2}}, nameMatch{distance: 0, id: SpeciesId{Pokemon: 6, Evolution: 3}}, true},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if got := test.match.better(test.other); got != test.expected {
+				t.Errorf("got %t, want %t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestPokemonNameFromGameMaster(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadGameMasterData("./test/gamemaster-test.json", loadGameMasterNames(t)); err != nil {
+		t.Fatalf("can't load game master: %s", err)
+	}
+
+	var tests = []struct {
+		pokemonId int
+		form      int
+		expected  string
+	}{
+		{52, 64, "Alola Meowth"},
+		{1, 163, "Bulbasaur"},
+		{3, 0, "Venusaur"},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if name := ohbem.PokemonName(test.pokemonId, test.form, 0); name != test.expected {
+				t.Errorf("got %s, want %s", name, test.expected)
+			}
+		})
+	}
+}
+
+func TestPokemonName(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := ohbem.LoadPokemonData("./test/master-test.json"); err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+	_ = ohbem.LoadNames("en", "./test/names-en-test.json")
+	_ = ohbem.LoadNames("de", "./test/names-de-test.json")
+
+	var tests = []struct {
+		locale    string
+		pokemonId int
+		form      int
+		evolution int
+		expected  string
+	}{
+		{"en", 618, 2345, 0, "Galarian Stunfisk"},
+		{"en", 3, 0, 1, "Mega Venusaur"},
+		{"en", 6, 0, 2, "Mega Charizard X"},
+		{"de", 6, 0, 2, "Mega-Glurak X"},
+		{"de", 618, 2345, 0, "Galar Flunschlik"},
+		{"en", 618, 2246, 0, "Stunfisk"},
+		{"en", 2, 0, 0, "#2"},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if name := ohbem.LocalizedPokemonName(test.locale, test.pokemonId, test.form, test.evolution); name != test.expected {
+				t.Errorf("got %s, want %s", name, test.expected)
+			}
+		})
+	}
+
+	if id, err := ohbem.ResolveCostume("Holiday 2016"); err != nil || id != 1 {
+		t.Errorf("got costume %d, %v", id, err)
+	}
+	if id, err := ohbem.ResolveCostume("feiertage 2016"); err != nil || id != 1 {
+		t.Errorf("got costume %d, %v", id, err)
+	}
+	if name := ohbem.CostumeName(25); name != "January 2020" {
+		t.Errorf("got costume name %s", name)
+	}
+
+	ohbem.HumanReadable = true
+	entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, 0, 14, 15, 1)
+	for _, entry := range entries["great"] {
+		if entry.Name != ohbem.PokemonName(entry.Pokemon, entry.Form, entry.Evolution) || entry.Name == "" {
+			t.Errorf("entry %+v should be named", entry)
+		}
+	}
+}
@@ -19,13 +19,16 @@ const VERSION = "0.12.0"
 
 // FetchPokemonData Fetch remote MasterFile and keep it in memory.
 func (o *Ohbem) FetchPokemonData() error {
-	var err error
-
-	o.PokemonData, err = fetchMasterFile()
+	pokemonData, err := fetchMasterFile(o.RemoteMasterFileURL)
 	if err != nil {
 		return err
 	}
-	o.ClearCache()
+	o.usePokemonData(pokemonData, MasterFileSourceRemote, time.Now())
+	if o.MasterFileHistoryPath != "" {
+		if _, err := o.SnapshotPokemonData(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -35,27 +38,58 @@ func (o *Ohbem) LoadPokemonData(filePath string) error {
 	if err != nil {
 		return ErrMasterFileOpen
 	}
-	if err := json.Unmarshal(data, &o.PokemonData); err != nil {
+	var pokemonData PokemonData
+	if err := json.Unmarshal(data, &pokemonData); err != nil {
 		return ErrMasterFileUnmarshall
 	}
-	o.PokemonData.Initialized = true
-	o.ClearCache()
+	pokemonData.raw = data
+	o.usePokemonData(pokemonData, MasterFileSourceFile, fileModTime(filePath))
+	return nil
+}
+
+// LoadCachedPokemonData Load MasterFile from MasterFileCachePath and refresh it from remote in background.
+// When cache can't be loaded, remote MasterFile is fetched immediately instead. Use MasterFileStatus to check which source is live.
+func (o *Ohbem) LoadCachedPokemonData() error {
+	if o.MasterFileCachePath == "" {
+		return o.FetchPokemonData()
+	}
+	if err := o.LoadPokemonData(o.MasterFileCachePath); err != nil {
+		o.log(fmt.Sprintf("Loading MasterFile cache from %s has failed, fetching remote", o.MasterFileCachePath))
+		if err := o.FetchPokemonData(); err != nil {
+			return err
+		}
+		if err := o.SavePokemonData(o.MasterFileCachePath); err != nil {
+			o.log(fmt.Sprintf("Storing MasterFile cache under %s has failed!", o.MasterFileCachePath))
+		}
+		return nil
+	}
+	o.setMasterFileStatus(MasterFileSourceCache, fileModTime(o.MasterFileCachePath))
+
+	go func() {
+		if _, err := o.refreshPokemonData(); err != nil {
+			o.log("Remote MasterFile fetch failed, serving cached MasterFile")
+		}
+	}()
 	return nil
 }
 
-// SavePokemonData Save MasterFile from memory to provided location.
+// SavePokemonData Save MasterFile from memory to provided location, atomically replacing existing file.
+// MasterFile is saved as it was loaded, without EvolutionRules applied.
 func (o *Ohbem) SavePokemonData(filePath string) error {
-	data, err := json.Marshal(o.PokemonData)
+	o.pokemonDataMutex.RLock()
+	data, err := rawPokemonData(&o.PokemonData)
+	o.pokemonDataMutex.RUnlock()
 	if err != nil {
-		return ErrMasterFileMarshall
+		return err
 	}
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := writeFileAtomic(filePath, data); err != nil {
 		return ErrMasterFileSave
 	}
 	return nil
 }
 
 // WatchPokemonData Watch for remote MasterFile changes. When new, auto-update and clean cache.
+// QueryPvPRank, QueryPvPRankWithOptions, ExplainPvPRank, SearchPvPRanks, FindPreEvolutions, QueryPreEvolutionRanks and FindBaseStats are safe to call while PokemonData is replaced.
 func (o *Ohbem) WatchPokemonData() error {
 	if o.watcherChan != nil {
 		return ErrWatcherStarted
@@ -83,26 +117,8 @@ func (o *Ohbem) WatchPokemonData() error {
 				return
 			case <-ticker.C:
 				o.log("Checking remote MasterFile")
-				pokemonData, err := fetchMasterFile()
-				if err != nil {
+				if _, err := o.refreshPokemonData(); err != nil {
 					o.log("Remote MasterFile fetch failed")
-					continue
-				}
-				if reflect.DeepEqual(o.PokemonData, pokemonData) {
-					continue
-				} else {
-					o.log("New MasterFile found! Updating PokemonData")
-					o.PokemonData = pokemonData // overwrite PokemonData using new MasterFile
-					o.PokemonData.Initialized = true
-					o.ClearCache() // clean compactRankCache cache
-					// when provided store latest version of MasterFile under provided path
-					if o.MasterFileCachePath != "" {
-						err = o.SavePokemonData(o.MasterFileCachePath)
-						if err != nil {
-							o.log(fmt.Sprintf("Storing MasterFile cache under %s has failed!", o.MasterFileCachePath))
-							continue
-						}
-					}
 				}
 			}
 		}
@@ -110,6 +126,54 @@ func (o *Ohbem) WatchPokemonData() error {
 	return nil
 }
 
+// refreshPokemonData Fetch remote MasterFile and replace PokemonData when changed. Returns true when PokemonData was replaced.
+// Refreshes of background loading and watcher are serialized.
+func (o *Ohbem) refreshPokemonData() (bool, error) {
+	o.refreshMutex.Lock()
+	defer o.refreshMutex.Unlock()
+
+	pokemonData, err := fetchMasterFile(o.RemoteMasterFileURL)
+	if err != nil {
+		return false, err
+	}
+	o.preparePokemonData(&pokemonData)
+	if reflect.DeepEqual(o.PokemonData, pokemonData) {
+		o.setMasterFileStatus(MasterFileSourceRemote, time.Now())
+		return false, nil
+	}
+	rejected, err := o.rejectedFingerprints()
+	if err != nil {
+		o.log(fmt.Sprintf("Loading rejected MasterFiles from %s has failed!", o.MasterFileHistoryPath))
+	}
+	if fingerprint := fingerprintMasterFile(pokemonData.raw); rejected[fingerprint] {
+		o.log(fmt.Sprintf("Remote MasterFile %s was rolled back, skipping", fingerprint))
+		return false, nil
+	}
+
+	diff := DiffPokemonData(o.PokemonData, pokemonData)
+	o.log(fmt.Sprintf("New MasterFile found! Updating PokemonData (%s)", diff))
+	// overwrite PokemonData using new MasterFile
+	pokemonData.Initialized = true
+	o.swapPokemonData(pokemonData)
+	o.PruneCache() // evict compactRankCache entries of removed base stats
+	if o.OnMasterFileChange != nil {
+		o.OnMasterFileChange(diff)
+	}
+	if o.MasterFileHistoryPath != "" {
+		if _, err := o.SnapshotPokemonData(); err != nil {
+			o.log(fmt.Sprintf("Storing MasterFile snapshot under %s has failed!", o.MasterFileHistoryPath))
+		}
+	}
+	// when provided store latest version of MasterFile under provided path
+	if o.MasterFileCachePath != "" {
+		if err := o.SavePokemonData(o.MasterFileCachePath); err != nil {
+			o.log(fmt.Sprintf("Storing MasterFile cache under %s has failed!", o.MasterFileCachePath))
+		}
+	}
+	o.setMasterFileStatus(MasterFileSourceRemote, time.Now())
+	return true, nil
+}
+
 // StopWatchingPokemonData Stop watching for remote MasterFile changes.
 func (o *Ohbem) StopWatchingPokemonData() error {
 	if o.watcherChan == nil {
@@ -127,47 +191,100 @@ func (o *Ohbem) ClearCache() {
 	}
 }
 
-// calculateAllRanksCompact Calculate all PvP ranks for a specific base stats with the specified CP cap. Compact version intended to be used with cache.
-func (o *Ohbem) calculateAllRanksCompact(stats *PokemonStats, cpCap int) (map[int]compactCacheValue, bool) {
-	cacheKey := int64(cpCap*999*999*999 + stats.Attack*999*999 + stats.Defense*999 + stats.Stamina)
+// PruneCache Evict cached ranks of base stats no longer present in PokemonData, keeping the rest warm.
+// Returns count of retained and evicted cache entries.
+func (o *Ohbem) PruneCache() (int, int) {
+	if o.DisableCache {
+		return 0, 0
+	}
+	present := make(map[[3]int]bool)
+	addStats := func(attack, defense, stamina int) {
+		present[[3]int{attack, defense, stamina}] = true
+	}
+	addTempEvolutions := func(tempEvolutions map[int]PokemonStats) {
+		for _, stats := range tempEvolutions {
+			addStats(stats.Attack, stats.Defense, stats.Stamina)
+		}
+	}
+	for _, masterPokemon := range o.PokemonData.Pokemon {
+		addStats(masterPokemon.Attack, masterPokemon.Defense, masterPokemon.Stamina)
+		addTempEvolutions(masterPokemon.TempEvolutions)
+		for _, masterForm := range masterPokemon.Forms {
+			if masterForm.Attack != 0 {
+				addStats(masterForm.Attack, masterForm.Defense, masterForm.Stamina)
+			}
+			addTempEvolutions(masterForm.TempEvolutions)
+		}
+	}
+
+	retained, evicted := 0, 0
+	o.compactRankCache.Range(func(key, _ any) bool {
+		cacheKey := key.(compactCacheKey)
+		if present[[3]int{cacheKey.Attack, cacheKey.Defense, cacheKey.Stamina}] {
+			retained++
+		} else {
+			o.compactRankCache.Delete(key)
+			evicted++
+		}
+		return true
+	})
+	o.log(fmt.Sprintf("Cache pruned: %d entries retained, %d evicted", retained, evicted))
+	return retained, evicted
+}
+
+// calculateAllRanksCompact Calculate all PvP ranks for a specific base stats with the specified CP cap, level caps and IV floor. Compact version intended to be used with cache.
+func (o *Ohbem) calculateAllRanksCompact(stats *PokemonStats, cpCap int, levelCaps []int, ivFloor int) (map[int]compactCacheValue, bool) {
+	cacheKey := compactCacheKey{
+		Cap:        cpCap,
+		Attack:     stats.Attack,
+		Defense:    stats.Defense,
+		Stamina:    stats.Stamina,
+		IvFloor:    ivFloor,
+		LevelCaps:  levelCapsMask(levelCaps),
+		Comparator: o.comparatorKey(),
+	}
 
 	if !o.DisableCache {
 		if obj, ok := o.compactRankCache.Load(cacheKey); ok {
 			return obj.(map[int]compactCacheValue), true
 		}
 	}
-	if o.RankingComparator == nil {
-		o.RankingComparator = RankingComparatorDefault
+	// cache key ignores order of level caps, so ranks are always calculated from the lowest one
+	if !sort.IntsAreSorted(levelCaps) {
+		levelCaps = append([]int(nil), levelCaps...)
+		sort.Ints(levelCaps)
 	}
 
 	filled := false
 	maxed := false
 	result := make(map[int]compactCacheValue)
 
-	for _, lvCap := range o.LevelCaps {
+	for _, lvCap := range levelCaps {
 		lvCapFloat := float64(lvCap)
 		if !o.IncludeHundosUnderCap && calculateCp(stats, 15, 15, 15, lvCapFloat) <= cpCap {
 			continue
 		}
 
-		combinations, sortedRanks := calculateRanksCompact(stats, cpCap, lvCapFloat, o.RankingComparator, 0)
+		combinations, sortedRanks, count := calculateRanksCompact(stats, cpCap, lvCapFloat, o.comparator(), ivFloor)
 		res := compactCacheValue{
 			Combinations: combinations,
-			TopValue:     sortedRanks[0].Value,
+			TopValue:     topStatProduct(sortedRanks, count),
+			Count:        count,
 		}
 		result[lvCap] = res
 		filled = true
-		if calculateCp(stats, 0, 0, 0, lvCapFloat+0.5) > cpCap {
+		if calculateCp(stats, ivFloor, ivFloor, ivFloor, lvCapFloat+0.5) > cpCap {
 			maxed = true
 			break
 		}
 	}
 	if filled && !maxed {
-		combinations, sortedRanks := calculateRanksCompact(stats, cpCap, MaxLevel, o.RankingComparator, 0)
+		combinations, sortedRanks, count := calculateRanksCompact(stats, cpCap, MaxLevel, o.comparator(), ivFloor)
 
 		res := compactCacheValue{
 			Combinations: combinations,
-			TopValue:     sortedRanks[0].Value,
+			TopValue:     topStatProduct(sortedRanks, count),
+			Count:        count,
 		}
 		result[MaxLevel] = res
 	}
@@ -260,6 +377,7 @@ func (o *Ohbem) CalculateTopRanks(maxRank int16, pokemonId int, form int, evolut
 	}
 
 	for leagueName, leagueOptions := range o.Leagues {
+		leagueOptions = namedLeague(leagueName, leagueOptions)
 		var rankings, lastRank []Ranking
 		var lastStat Ranking
 
@@ -306,7 +424,7 @@ func (o *Ohbem) CalculateTopRanks(maxRank int16, pokemonId int, form int, evolut
 
 		if leagueOptions.LittleCupRules && !(masterForm.Little || masterPokemon.Little) {
 			continue
-		} else if leagueName == "master" {
+		} else if leagueOptions.IsUncapped() {
 			for _, lvCap := range o.LevelCaps {
 				lvCapFloat := float64(lvCap)
 				maxHp := calculateHp(stats, 15, lvCapFloat)
@@ -355,9 +473,18 @@ func (o *Ohbem) CalculateTopRanks(maxRank int16, pokemonId int, form int, evolut
 
 // CalculateCp calculates CP for your pokemon. Errors if pokemon cannot be found in master.
 func (o *Ohbem) CalculateCp(pokemonId, form, evolution, attack, defense, stamina int, level float64) (int, error) {
+	stats, err := o.resolveStats(pokemonId, form, evolution)
+	if err != nil {
+		return 0, err
+	}
+	return calculateCp(&stats, attack, defense, stamina, level), nil
+}
+
+// resolveStats returns base stats of Pokemon form or temp evolution, falling back to Pokemon stats.
+func (o *Ohbem) resolveStats(pokemonId, form, evolution int) (PokemonStats, error) {
 	masterPokemon, ok := o.PokemonData.Pokemon[pokemonId]
 	if !ok {
-		return 0, ErrMissingPokemon
+		return PokemonStats{}, ErrMissingPokemon
 	}
 	masterForm, ok := masterPokemon.Forms[form]
 	if !ok || form == 0 {
@@ -377,6 +504,7 @@ func (o *Ohbem) CalculateCp(pokemonId, form, evolution, attack, defense, stamina
 		stats.Attack = masterEvo.Attack
 		stats.Defense = masterEvo.Defense
 		stats.Stamina = masterEvo.Stamina
+		stats.Types = masterEvo.Types
 	} else if masterForm.Attack != 0 {
 		stats.Attack = masterForm.Attack
 		stats.Defense = masterForm.Defense
@@ -386,11 +514,44 @@ func (o *Ohbem) CalculateCp(pokemonId, form, evolution, attack, defense, stamina
 		stats.Defense = masterPokemon.Defense
 		stats.Stamina = masterPokemon.Stamina
 	}
-	return calculateCp(&stats, attack, defense, stamina, level), nil
+	return stats, nil
 }
 
 // QueryPvPRank Query all ranks for a specific Pokémon, including its possible evolutions.
+// Unreleased temp evolutions are included and flagged, unless ExcludeUnreleased is set.
 func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, attack int, defense int, stamina int, level float64) (map[string][]PokemonEntry, error) {
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	return o.queryPvPRank(pokemonId, form, costume, gender, attack, defense, stamina, level, QueryOptions{}, nil)
+}
+
+// QueryPvPRankWithOptions Query all ranks like QueryPvPRank, with options overriding Ohbem defaults for this query.
+// Options left nil keep Ohbem defaults, e.g. QueryOptions{} queries like QueryPvPRank.
+func (o *Ohbem) QueryPvPRankWithOptions(pokemonId int, form int, costume int, gender int, attack int, defense int, stamina int, level float64, options QueryOptions) (map[string][]PokemonEntry, error) {
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	return o.queryPvPRank(pokemonId, form, costume, gender, attack, defense, stamina, level, options, nil)
+}
+
+// ExplainPvPRank Query all ranks like QueryPvPRank, additionally returning decisions explaining skipped and capped entries per league and evolution.
+func (o *Ohbem) ExplainPvPRank(pokemonId int, form int, costume int, gender int, attack int, defense int, stamina int, level float64) (map[string][]PokemonEntry, []QueryDecision, error) {
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	trace := &queryTrace{}
+	result, err := o.queryPvPRank(pokemonId, form, costume, gender, attack, defense, stamina, level, QueryOptions{}, trace)
+	return result, trace.decisions, err
+}
+
+// excludeUnreleased returns ExcludeUnreleased option, fallback (Ohbem default) when unset.
+func (q QueryOptions) excludeUnreleased(fallback bool) bool {
+	if q.ExcludeUnreleased == nil {
+		return fallback
+	}
+	return *q.ExcludeUnreleased
+}
+
+// queryPvPRank is core of QueryPvPRank, recording decisions into trace when provided.
+func (o *Ohbem) queryPvPRank(pokemonId int, form int, costume int, gender int, attack int, defense int, stamina int, level float64, options QueryOptions, trace *queryTrace) (map[string][]PokemonEntry, error) {
 	result := make(map[string][]PokemonEntry)
 
 	if err := safetyCheck(o); err != nil {
@@ -401,7 +562,6 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 		return result, ErrQueryInputOutOfRange
 	}
 
-	var masterForm Form
 	var masterPokemon Pokemon
 	var baseEntry = PokemonEntry{Pokemon: pokemonId}
 
@@ -411,30 +571,49 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 		return result, ErrMissingPokemon
 	}
 
-	if _, ok := masterPokemon.Forms[form]; ok && form != 0 {
+	masterForm, ok := resolveForm(&masterPokemon, form)
+	if ok {
 		baseEntry.Form = form
-		masterForm = masterPokemon.Forms[form]
-	} else {
-		masterForm = Form{
-			Attack:                    masterPokemon.Attack,
-			Defense:                   masterPokemon.Defense,
-			Stamina:                   masterPokemon.Stamina,
-			Little:                    masterPokemon.Little,
-			Evolutions:                masterPokemon.Evolutions,
-			TempEvolutions:            masterPokemon.TempEvolutions,
-			CostumeOverrideEvolutions: masterPokemon.CostumeOverrideEvolutions,
-		}
+	}
+	types := masterForm.Types
+	if len(types) == 0 {
+		types = masterPokemon.Types
 	}
 
 	pushAllEntries := func(stats *PokemonStats, evolution int) {
+		types := types
+		if len(stats.Types) != 0 {
+			types = stats.Types
+		}
 		for leagueName, leagueOptions := range o.Leagues {
+			leagueOptions = namedLeague(leagueName, leagueOptions)
 			var entries []PokemonEntry
+			decision := QueryDecision{Pokemon: pokemonId, Form: baseEntry.Form, Evolution: evolution, League: leagueName}
 
-			if leagueName != "master" {
+			if reason := leagueOptions.eligibility(pokemonId, baseEntry.Form, types); reason != "" {
+				trace.add(decision, reason)
+				continue
+			}
+			if !leagueOptions.hasIvFloor(attack, defense, stamina) {
+				trace.add(decision, DecisionBelowIvFloor)
+				continue
+			}
+			levelCaps := leagueOptions.levelCaps(o.LevelCaps)
+			if !leagueOptions.IsUncapped() {
 				if leagueOptions.LittleCupRules && !(masterForm.Little || masterPokemon.Little) {
+					trace.add(decision, DecisionLittleCupIneligible)
 					continue
 				}
-				combinationIndex, filled := o.calculateAllRanksCompact(stats, leagueOptions.Cap)
+				combinationIndex, filled := o.calculateAllRanksCompact(stats, leagueOptions.Cap, levelCaps, leagueOptions.IvFloor)
+				if trace != nil && !o.IncludeHundosUnderCap {
+					for _, lvCap := range levelCaps {
+						if _, ok := combinationIndex[lvCap]; !ok && calculateCp(stats, 15, 15, 15, float64(lvCap)) <= leagueOptions.Cap {
+							decision.Cap = float64(lvCap)
+							trace.add(decision, DecisionHundoUnderCap)
+						}
+					}
+					decision.Cap = 0
+				}
 				if !filled {
 					continue
 				}
@@ -442,6 +621,10 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 				processCombinations := func(pCap float64, combinations compactCacheValue) {
 					var stat PvPRankingStats
 					if err := calculatePvPStat(&stat, stats, attack, defense, stamina, leagueOptions.Cap, pCap, level); err != nil {
+						if pCap != MaxLevel {
+							decision.Cap = pCap
+							trace.add(decision, DecisionLevelAboveCap)
+						}
 						return
 					}
 					entry := PokemonEntry{
@@ -453,10 +636,16 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 						Cp:         stat.Cp,
 						Percentage: roundFloat(stat.Value/combinations.TopValue, 5),
 						Rank:       combinations.Combinations[(attack*16+defense)*16+stamina],
+						Count:      combinations.Count,
+					}
+					if combinations.Count != 0 {
+						entry.Percentile = roundFloat(float64(entry.Rank)/float64(combinations.Count), 5)
 					}
+					entry.Bucket = o.rankBucket(entry.Rank, entry.Percentile)
 
 					if evolution != 0 {
 						entry.Evolution = evolution
+						entry.Unreleased = stats.Unreleased
 					}
 					entries = append(entries, entry)
 				}
@@ -474,6 +663,8 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 				}
 
 				if len(entries) == 0 {
+					decision.Cap = 0
+					trace.add(decision, DecisionAboveLeagueCp)
 					continue
 				}
 				last := &entries[len(entries)-1]
@@ -487,22 +678,30 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 				}
 				if last.Cap < MaxLevel {
 					last.Capped = true
+					decision.Cap = last.Cap
+					trace.add(decision, DecisionCapped)
 				} else {
 					if len(entries) == 1 {
+						decision.Cap = last.Cap
+						trace.add(decision, DecisionOnlyMaxLevel)
 						continue
 					}
 					entries = entries[:len(entries)-1]
 				}
 			} else if evolution == 0 && attack == 15 && defense == 15 && stamina < 15 {
-				for _, lvCap := range o.LevelCaps {
+				for _, lvCap := range levelCaps {
 					lvCapFloat := float64(lvCap)
-					if calculateHp(stats, stamina, lvCapFloat) == calculateHp(stats, 15, lvCapFloat) {
+					if calculateHp(stats, stamina, lvCapFloat) != calculateHp(stats, 15, lvCapFloat) {
+						decision.Cap = lvCapFloat
+						trace.add(decision, DecisionNotFunctionallyPerfect)
+					} else {
 						entry := PokemonEntry{
 							Pokemon:    baseEntry.Pokemon,
 							Form:       baseEntry.Form,
 							Level:      lvCapFloat,
 							Percentage: 1,
 							Rank:       1,
+							Bucket:     o.rankBucket(1, 0),
 						}
 						entries = append(entries, entry)
 					}
@@ -510,9 +709,20 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 				if len(entries) == 0 {
 					continue
 				}
+			} else if evolution != 0 {
+				trace.add(decision, DecisionTempEvolutionMaxLevel)
+				continue
 			} else {
+				trace.add(decision, DecisionNotFunctionallyPerfect)
 				continue
 			}
+			if o.HumanReadable {
+				for ix := range entries {
+					entries[ix].Name = o.PokemonName(entries[ix].Pokemon, entries[ix].Form, entries[ix].Evolution)
+				}
+			}
+			decision.Cap = 0
+			trace.add(decision, DecisionIncluded)
 			if result[leagueName] == nil {
 				result[leagueName] = entries
 			} else {
@@ -522,35 +732,20 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 	}
 
 	if masterForm.Attack != 0 {
-		pushAllEntries(&PokemonStats{masterForm.Attack, masterForm.Defense, masterForm.Stamina, false}, 0)
+		pushAllEntries(&PokemonStats{Attack: masterForm.Attack, Defense: masterForm.Defense, Stamina: masterForm.Stamina}, 0)
 	} else {
-		pushAllEntries(&PokemonStats{masterPokemon.Attack, masterPokemon.Defense, masterPokemon.Stamina, false}, 0)
+		pushAllEntries(&PokemonStats{Attack: masterPokemon.Attack, Defense: masterPokemon.Defense, Stamina: masterPokemon.Stamina}, 0)
 	}
 
-	canEvolve := true
-	if costume != 0 {
-		canEvolve = !o.PokemonData.Costumes[costume] || containsInt(masterForm.CostumeOverrideEvolutions, costume)
+	var edges []EvolutionEdge
+	if !options.noEvolutions {
+		edges = o.evolutionEdges(pokemonId, baseEntry.Form, &masterForm, costume, gender, attack, defense, stamina)
 	}
-	if canEvolve && len(masterForm.Evolutions) != 0 {
-		for _, evolution := range masterForm.Evolutions {
-			switch evolution.Pokemon {
-			case 106:
-				if attack < defense || attack < stamina {
-					continue
-				}
-			case 107:
-				if defense < attack || defense < stamina {
-					continue
-				}
-			case 237:
-				if stamina < attack || stamina < defense {
-					continue
-				}
-			}
-			if evolution.GenderRequirement != 0 && gender != evolution.GenderRequirement {
-				continue
-			}
-			evolvedRanks, _ := o.QueryPvPRank(evolution.Pokemon, evolution.Form, costume, gender, attack, defense, stamina, level)
+	for _, edge := range edges {
+		trace.addEvolution(edge)
+		if edge.Reachable {
+			evolution := edge.Target
+			evolvedRanks, _ := o.queryPvPRank(evolution.Pokemon, evolution.Form, costume, gender, attack, defense, stamina, level, options, trace)
 			for leagueName, results := range evolvedRanks {
 				if result[leagueName] == nil {
 					result[leagueName] = results
@@ -563,12 +758,22 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 
 	if len(masterForm.TempEvolutions) != 0 {
 		for tempEvoId, tempEvo := range masterForm.TempEvolutions {
-			if tempEvo.Attack != 0 {
-				pushAllEntries(&tempEvo, tempEvoId)
-			} else {
-				t := masterPokemon.TempEvolutions[tempEvoId]
-				pushAllEntries(&t, tempEvoId)
+			if tempEvo.Attack == 0 {
+				unreleased := tempEvo.Unreleased
+				tempEvo = masterPokemon.TempEvolutions[tempEvoId]
+				tempEvo.Unreleased = tempEvo.Unreleased || unreleased
+			}
+			if tempEvo.Unreleased && options.excludeUnreleased(o.ExcludeUnreleased) {
+				trace.add(QueryDecision{Pokemon: pokemonId, Form: baseEntry.Form, Evolution: tempEvoId}, DecisionUnreleased)
+				continue
 			}
+			pushAllEntries(&tempEvo, tempEvoId)
+		}
+	}
+
+	if !options.noEvolutions && !options.noFormChanges {
+		for leagueName, results := range o.queryFormChanges(pokemonId, baseEntry.Form, &masterForm, costume, gender, attack, defense, stamina, level, options, trace) {
+			result[leagueName] = append(result[leagueName], results...)
 		}
 	}
 
@@ -577,6 +782,8 @@ func (o *Ohbem) QueryPvPRank(pokemonId int, form int, costume int, gender int, a
 
 // FindBaseStats Look up base stats of a Pokémon.
 func (o *Ohbem) FindBaseStats(pokemonId int, form int, evolution int) (PokemonStats, error) {
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
 	if err := safetyCheck(o); err != nil {
 		return PokemonStats{}, err
 	}
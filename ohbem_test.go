@@ -2,6 +2,7 @@ package gohbem
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -52,7 +53,7 @@ func TestCalculateAllRanksCompact(t *testing.T) {
 	for ix, test := range tests {
 		testName := fmt.Sprintf("%d", ix)
 		t.Run(testName, func(t *testing.T) {
-			combinations, _ := ohbem.calculateAllRanksCompact(&test.stats, test.cpCap)
+			combinations, _ := ohbem.calculateAllRanksCompact(&test.stats, test.cpCap, levelCaps, 0)
 			comb := combinations[test.lvCap].Combinations
 			topValue := combinations[test.lvCap].TopValue
 			if comb[test.pos] != test.value || topValue != test.topVal {
@@ -62,13 +63,40 @@ func TestCalculateAllRanksCompact(t *testing.T) {
 	}
 }
 
+func TestCalculateAllRanksCompactLevelCapsOrder(t *testing.T) {
+	talonflame := PokemonStats{Attack: 176, Defense: 155, Stamina: 186}
+
+	var tests = []struct {
+		first  []int
+		second []int
+	}{
+		{[]int{50, 40}, []int{40, 50}},
+		{[]int{40, 50}, []int{50, 40}},
+		{[]int{51, 40, 50}, []int{40, 50, 51}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+			first, _ := ohbem.calculateAllRanksCompact(&talonflame, 1500, test.first, 0)
+			second, _ := ohbem.calculateAllRanksCompact(&talonflame, 1500, test.second, 0)
+			uncached := Ohbem{Leagues: leagues, LevelCaps: levelCaps, DisableCache: true}
+			expected, _ := uncached.calculateAllRanksCompact(&talonflame, 1500, test.second, 0)
+			if !reflect.DeepEqual(first, expected) || !reflect.DeepEqual(second, expected) {
+				t.Errorf("got caps %v and %v, want %v", sortedKeys(first), sortedKeys(second), sortedKeys(expected))
+			}
+		})
+	}
+}
+
 func BenchmarkCalculateAllRanksCompact(b *testing.B) {
 	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, DisableCache: true}
 	_ = ohbem.LoadPokemonData("./test/master-test.json")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = ohbem.calculateAllRanksCompact(&PikachuStats, 5000)
+		_, _ = ohbem.calculateAllRanksCompact(&PikachuStats, 5000, levelCaps, 0)
 	}
 }
 
@@ -78,7 +106,7 @@ func BenchmarkCalculateAllRanksCompactCached(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = ohbem.calculateAllRanksCompact(&PikachuStats, 5000)
+		_, _ = ohbem.calculateAllRanksCompact(&PikachuStats, 5000, levelCaps, 0)
 	}
 }
 
@@ -284,6 +312,32 @@ func TestQueryPvPRank(t *testing.T) {
 	}
 }
 
+func TestQueryPvPRankUncappedLeagues(t *testing.T) {
+	ohbem := Ohbem{Leagues: map[string]League{
+		"master":         {Uncapped: true},
+		"master_premier": {Cap: 10000, Uncapped: true},
+		"master_classic": {Cap: 0},
+	}, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	entries, _ := ohbem.QueryPvPRank(661, 0, 0, 1, 15, 15, 14, 1)
+	for _, league := range []string{"master", "master_premier", "master_classic"} {
+		t.Run(league, func(t *testing.T) {
+			if len(entries[league]) != 3 {
+				t.Errorf("got %d entries, want 3", len(entries[league]))
+			}
+			for _, entry := range entries[league] {
+				if entry.Rank != 1 || entry.Percentage != 1 || entry.Cap != 0 {
+					t.Errorf("got %+v, want functionally perfect entry", entry)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkQueryPvPRank(b *testing.B) {
 	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, DisableCache: true}
 	_ = ohbem.LoadPokemonData("./test/master-test.json")
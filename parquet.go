@@ -0,0 +1,302 @@
+package gohbem
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// parquetRowGroupSize is count of rows buffered before ExportRankTableParquet writes a row group.
+const parquetRowGroupSize = 65536
+
+// Parquet physical types, encodings and Thrift compact protocol field types used by parquetWriter.
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetEncodingPlain = 0
+	parquetEncodingRle   = 3
+
+	parquetConvertedUtf8 = 0
+
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// parquetColumn is holding PLAIN encoded values of one required column in current row group.
+type parquetColumn struct {
+	name     string
+	kind     int32
+	data     []byte
+	bits     int
+	pageSize int64
+	offset   int64
+}
+
+// parquetRowGroup is holding metadata of written row group.
+type parquetRowGroup struct {
+	rows    int64
+	columns []parquetColumn
+}
+
+// parquetWriter is minimal uncompressed Parquet writer of flat schema with required columns, one data page per column chunk.
+type parquetWriter struct {
+	w         io.Writer
+	offset    int64
+	columns   []parquetColumn
+	rows      int64
+	total     int64
+	rowGroups []parquetRowGroup
+}
+
+// thriftWriter is encoding Thrift compact protocol structs.
+type thriftWriter struct {
+	buf     []byte
+	lastIds []int16
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	t.buf = binary.AppendUvarint(t.buf, v)
+}
+
+func (t *thriftWriter) field(id int16, kind byte) {
+	last := t.lastIds[len(t.lastIds)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|kind)
+	} else {
+		t.buf = append(t.buf, kind)
+		t.varint(uint64((id << 1) ^ (id >> 15)))
+	}
+	t.lastIds[len(t.lastIds)-1] = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) binary(id int16, v string) {
+	t.field(id, thriftBinary)
+	t.varint(uint64(len(v)))
+	t.buf = append(t.buf, v...)
+}
+
+func (t *thriftWriter) list(id int16, kind byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf = append(t.buf, byte(size)<<4|kind)
+	} else {
+		t.buf = append(t.buf, 0xf0|kind)
+		t.varint(uint64(size))
+	}
+}
+
+// begin starts struct, either as field id or list element when id is 0.
+func (t *thriftWriter) begin(id int16) {
+	if id != 0 {
+		t.field(id, thriftStruct)
+	}
+	t.lastIds = append(t.lastIds, 0)
+}
+
+func (t *thriftWriter) end() {
+	t.buf = append(t.buf, 0)
+	t.lastIds = t.lastIds[:len(t.lastIds)-1]
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastIds: []int16{0}}
+}
+
+// newParquetWriter writes Parquet magic and returns parquetWriter of provided columns.
+func newParquetWriter(w io.Writer, columns []parquetColumn) (*parquetWriter, error) {
+	p := &parquetWriter{w: w, columns: columns}
+	return p, p.write([]byte("PAR1"))
+}
+
+func (p *parquetWriter) write(b []byte) error {
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	return err
+}
+
+func (p *parquetWriter) int32(column int, v int32) {
+	p.columns[column].data = binary.LittleEndian.AppendUint32(p.columns[column].data, uint32(v))
+}
+
+func (p *parquetWriter) double(column int, v float64) {
+	p.columns[column].data = binary.LittleEndian.AppendUint64(p.columns[column].data, math.Float64bits(v))
+}
+
+func (p *parquetWriter) string(column int, v string) {
+	c := &p.columns[column]
+	c.data = binary.LittleEndian.AppendUint32(c.data, uint32(len(v)))
+	c.data = append(c.data, v...)
+}
+
+func (p *parquetWriter) boolean(column int, v bool) {
+	c := &p.columns[column]
+	if c.bits%8 == 0 {
+		c.data = append(c.data, 0)
+	}
+	if v {
+		c.data[len(c.data)-1] |= 1 << (c.bits % 8)
+	}
+	c.bits++
+}
+
+// endRow counts written row and flushes row group when full.
+func (p *parquetWriter) endRow() error {
+	p.rows++
+	if p.rows >= parquetRowGroupSize {
+		return p.flush()
+	}
+	return nil
+}
+
+// flush writes buffered rows as row group.
+func (p *parquetWriter) flush() error {
+	if p.rows == 0 {
+		return nil
+	}
+	group := parquetRowGroup{rows: p.rows, columns: make([]parquetColumn, len(p.columns))}
+	for ix := range p.columns {
+		c := &p.columns[ix]
+		header := newThriftWriter()
+		header.i32(1, 0) // DATA_PAGE
+		header.i32(2, int32(len(c.data)))
+		header.i32(3, int32(len(c.data)))
+		header.begin(5)
+		header.i32(1, int32(p.rows))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRle)
+		header.i32(4, parquetEncodingRle)
+		header.end()
+		header.end()
+
+		group.columns[ix] = parquetColumn{name: c.name, kind: c.kind, offset: p.offset, pageSize: int64(len(header.buf) + len(c.data))}
+		if err := p.write(header.buf); err != nil {
+			return err
+		}
+		if err := p.write(c.data); err != nil {
+			return err
+		}
+		c.data, c.bits = c.data[:0], 0
+	}
+	p.rowGroups = append(p.rowGroups, group)
+	p.total += p.rows
+	p.rows = 0
+	return nil
+}
+
+// close flushes buffered rows and writes file metadata.
+func (p *parquetWriter) close() error {
+	if err := p.flush(); err != nil {
+		return err
+	}
+	meta := newThriftWriter()
+	meta.i32(1, 1)
+	meta.list(2, thriftStruct, len(p.columns)+1)
+	meta.begin(0)
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(p.columns)))
+	meta.end()
+	for _, c := range p.columns {
+		meta.begin(0)
+		meta.i32(1, c.kind)
+		meta.i32(3, 0) // REQUIRED
+		meta.binary(4, c.name)
+		if c.kind == parquetByteArray {
+			meta.i32(6, parquetConvertedUtf8)
+		}
+		meta.end()
+	}
+	meta.i64(3, p.total)
+	meta.list(4, thriftStruct, len(p.rowGroups))
+	for _, group := range p.rowGroups {
+		var size int64
+		meta.begin(0)
+		meta.list(1, thriftStruct, len(group.columns))
+		for _, c := range group.columns {
+			size += c.pageSize
+			meta.begin(0)
+			meta.i64(2, c.offset)
+			meta.begin(3)
+			meta.i32(1, c.kind)
+			meta.list(2, thriftI32, 2)
+			meta.varint(parquetEncodingPlain)
+			meta.varint(parquetEncodingRle << 1)
+			meta.list(3, thriftBinary, 1)
+			meta.varint(uint64(len(c.name)))
+			meta.buf = append(meta.buf, c.name...)
+			meta.i32(4, 0) // UNCOMPRESSED
+			meta.i64(5, group.rows)
+			meta.i64(6, c.pageSize)
+			meta.i64(7, c.pageSize)
+			meta.i64(9, c.offset)
+			meta.end()
+			meta.end()
+		}
+		meta.i64(2, size)
+		meta.i64(3, group.rows)
+		meta.end()
+	}
+	meta.binary(6, "gohbem")
+	meta.end()
+
+	if err := p.write(meta.buf); err != nil {
+		return err
+	}
+	if err := p.write(binary.LittleEndian.AppendUint32(nil, uint32(len(meta.buf)))); err != nil {
+		return err
+	}
+	return p.write([]byte("PAR1"))
+}
+
+// ExportRankTableParquet Write rank table selected by query to w as uncompressed Parquet file.
+// Columns are the same as in ExportRankTableCSV, rows are written in row groups of up to 65536 rows.
+func (o *Ohbem) ExportRankTableParquet(w io.Writer, query RankTableQuery) error {
+	kinds := []int32{
+		parquetInt32, parquetInt32, parquetInt32, parquetByteArray, parquetDouble, parquetInt32, parquetInt32, parquetInt32,
+		parquetDouble, parquetInt32, parquetDouble, parquetDouble, parquetInt32, parquetBoolean,
+	}
+	columns := make([]parquetColumn, len(rankTableColumns))
+	for ix, name := range rankTableColumns {
+		columns[ix] = parquetColumn{name: name, kind: kinds[ix]}
+	}
+	p, err := newParquetWriter(w, columns)
+	if err != nil {
+		return err
+	}
+	err = o.RankTable(query, func(row RankTableRow) error {
+		p.int32(0, int32(row.Pokemon))
+		p.int32(1, int32(row.Form))
+		p.int32(2, int32(row.Evolution))
+		p.string(3, row.League)
+		p.double(4, row.Cap)
+		p.int32(5, int32(row.Attack))
+		p.int32(6, int32(row.Defense))
+		p.int32(7, int32(row.Stamina))
+		p.double(8, row.Level)
+		p.int32(9, int32(row.Cp))
+		p.double(10, row.Value)
+		p.double(11, row.Percentage)
+		p.int32(12, int32(row.Rank))
+		p.boolean(13, row.Capped)
+		return p.endRow()
+	})
+	if err != nil {
+		return err
+	}
+	return p.close()
+}
@@ -0,0 +1,139 @@
+package gohbem
+
+// buildPreEvolutions indexes direct pre-evolutions of every evolution target (pokemon, form).
+// Evolutions of Pokemon itself are skipped when one of its forms evolves into the same Pokemon, so ancestors aren't listed twice.
+func buildPreEvolutions(data *PokemonData) {
+	index := make(map[[2]int][]PreEvolution)
+	add := func(pokemonId, form int, evolutions []Evolution) {
+		for _, evolution := range evolutions {
+			key := [2]int{evolution.Pokemon, evolution.Form}
+			pre := PreEvolution{Pokemon: pokemonId, Form: form, Steps: 1}
+			if !containsPreEvolution(index[key], pre) {
+				index[key] = append(index[key], pre)
+			}
+		}
+	}
+	for pokemonId, masterPokemon := range data.Pokemon {
+		formTargets := make(map[int]bool)
+		for formId, masterForm := range masterPokemon.Forms {
+			if formId == 0 {
+				continue
+			}
+			add(pokemonId, formId, masterForm.Evolutions)
+			for _, evolution := range masterForm.Evolutions {
+				formTargets[evolution.Pokemon] = true
+			}
+		}
+		var evolutions []Evolution
+		for _, evolution := range masterPokemon.Evolutions {
+			if !formTargets[evolution.Pokemon] {
+				evolutions = append(evolutions, evolution)
+			}
+		}
+		add(pokemonId, 0, evolutions)
+	}
+	data.preEvolutions = index
+}
+
+// ensurePreEvolutions builds pre-evolution index of PokemonData assigned directly, without Load*/Fetch*.
+func (o *Ohbem) ensurePreEvolutions() {
+	o.pokemonDataMutex.RLock()
+	built := o.PokemonData.preEvolutions != nil
+	o.pokemonDataMutex.RUnlock()
+	if built {
+		return
+	}
+	o.pokemonDataMutex.Lock()
+	if o.PokemonData.preEvolutions == nil {
+		buildPreEvolutions(&o.PokemonData)
+	}
+	o.pokemonDataMutex.Unlock()
+}
+
+func containsPreEvolution(slice []PreEvolution, value PreEvolution) bool {
+	for _, v := range slice {
+		if v.Pokemon == value.Pokemon && v.Form == value.Form {
+			return true
+		}
+	}
+	return false
+}
+
+// directPreEvolutions returns direct pre-evolutions of Pokemon form, form 0 matches every form.
+func (o *Ohbem) directPreEvolutions(pokemonId, form int) []PreEvolution {
+	if form != 0 {
+		return o.PokemonData.preEvolutions[[2]int{pokemonId, form}]
+	}
+	var result []PreEvolution
+	for key, preEvolutions := range o.PokemonData.preEvolutions {
+		if key[0] != pokemonId {
+			continue
+		}
+		for _, pre := range preEvolutions {
+			if !containsPreEvolution(result, pre) {
+				result = append(result, pre)
+			}
+		}
+	}
+	return result
+}
+
+// FindPreEvolutions Look up all Pokémon forms evolving (directly or through several evolutions) into a specific Pokémon.
+// Form 0 matches every form of the Pokémon.
+func (o *Ohbem) FindPreEvolutions(pokemonId int, form int) ([]PreEvolution, error) {
+	var result []PreEvolution
+
+	if err := safetyCheck(o); err != nil {
+		return result, err
+	}
+	o.ensurePreEvolutions()
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	if _, ok := o.PokemonData.Pokemon[pokemonId]; !ok {
+		return result, ErrMissingPokemon
+	}
+
+	queue := o.directPreEvolutions(pokemonId, form)
+	for len(queue) != 0 {
+		pre := queue[0]
+		queue = queue[1:]
+		if containsPreEvolution(result, pre) || pre.Pokemon == pokemonId {
+			continue
+		}
+		result = append(result, pre)
+		for _, next := range o.directPreEvolutions(pre.Pokemon, pre.Form) {
+			next.Steps = pre.Steps + 1
+			queue = append(queue, next)
+		}
+	}
+	return result, nil
+}
+
+// QueryPreEvolutionRanks Query ranks of a specific Pokémon in league when evolved from each of its pre-evolutions with provided IVs.
+// PreEvolutionRank without Entries means the Pokémon can't be reached (e.g. gender or IV requirement) or isn't ranked.
+func (o *Ohbem) QueryPreEvolutionRanks(pokemonId int, form int, league string, gender int, attack int, defense int, stamina int, level float64) ([]PreEvolutionRank, error) {
+	var result []PreEvolutionRank
+
+	if _, ok := o.Leagues[league]; !ok {
+		return result, ErrLeagueMissing
+	}
+	preEvolutions, err := o.FindPreEvolutions(pokemonId, form)
+	if err != nil {
+		return result, err
+	}
+
+	for _, pre := range preEvolutions {
+		entries, err := o.QueryPvPRank(pre.Pokemon, pre.Form, 0, gender, attack, defense, stamina, level)
+		if err != nil {
+			return result, err
+		}
+		rank := PreEvolutionRank{PreEvolution: pre}
+		for _, entry := range entries[league] {
+			if entry.Pokemon == pokemonId && (form == 0 || entry.Form == form) {
+				rank.Entries = append(rank.Entries, entry)
+			}
+		}
+		result = append(result, rank)
+	}
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package gohbem
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestFindPreEvolutions(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var tests = []struct {
+		pokemonId int
+		form      int
+		expected  map[int]int
+	}{
+		{663, 0, map[int]int{661: 2, 662: 1}},
+		{662, 0, map[int]int{661: 1}},
+		{478, 0, map[int]int{361: 1}},
+		{107, 0, map[int]int{236: 1}},
+		{661, 0, map[int]int{}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			preEvolutions, err := ohbem.FindPreEvolutions(test.pokemonId, test.form)
+			if err != nil {
+				t.Errorf("got %v, want nil", err)
+			}
+			found := make(map[int]int)
+			for _, pre := range preEvolutions {
+				if steps, ok := found[pre.Pokemon]; ok && steps != pre.Steps {
+					t.Errorf("%d: got %d steps, want %d", pre.Pokemon, pre.Steps, steps)
+				}
+				found[pre.Pokemon] = pre.Steps
+			}
+			if len(found) != len(test.expected) {
+				t.Errorf("got %v, want %v", found, test.expected)
+			}
+			for pokemonId, steps := range test.expected {
+				if found[pokemonId] != steps {
+					t.Errorf("%d: got %d steps, want %d", pokemonId, found[pokemonId], steps)
+				}
+			}
+		})
+	}
+
+	if _, err := ohbem.FindPreEvolutions(100000, 0); err != ErrMissingPokemon {
+		t.Errorf("got %v, want %v", err, ErrMissingPokemon)
+	}
+}
+
+func TestFindPreEvolutionsForms(t *testing.T) {
+	raw, err := os.ReadFile("./test/master-test.json")
+	if err != nil {
+		t.Fatalf("can't read MasterFile")
+	}
+	var pokemonData PokemonData
+	if err := json.Unmarshal(raw, &pokemonData); err != nil {
+		t.Fatalf("can't unmarshal MasterFile")
+	}
+	pokemonData.Initialized = true
+	assigned := Ohbem{Leagues: leagues, LevelCaps: levelCaps, PokemonData: pokemonData}
+	loaded := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	if err := loaded.LoadPokemonData("./test/master-test.json"); err != nil {
+		t.Fatalf("can't load MasterFile")
+	}
+
+	for name, ohbem := range map[string]*Ohbem{"assigned": &assigned, "loaded": &loaded} {
+		t.Run(name, func(t *testing.T) {
+			preEvolutions, err := ohbem.FindPreEvolutions(3, 0)
+			if err != nil {
+				t.Fatalf("got %v, want nil", err)
+			}
+			expected := []PreEvolution{{Pokemon: 2, Form: 166, Steps: 1}, {Pokemon: 1, Form: 163, Steps: 2}}
+			if !reflect.DeepEqual(preEvolutions, expected) {
+				t.Errorf("got %+v, want %+v", preEvolutions, expected)
+			}
+		})
+	}
+}
+
+func TestQueryPreEvolutionRanks(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	ranks, err := ohbem.QueryPreEvolutionRanks(107, 0, "great", 1, 15, 10, 10, 1)
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if len(ranks) != 1 || ranks[0].Pokemon != 236 || len(ranks[0].Entries) != 0 {
+		t.Errorf("got %+v, want Tyrogue without entries", ranks)
+	}
+
+	ranks, err = ohbem.QueryPreEvolutionRanks(663, 0, "great", 1, 0, 15, 15, 1)
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if len(ranks) == 0 {
+		t.Errorf("got %+v, want pre-evolutions", ranks)
+	}
+	for _, rank := range ranks {
+		if len(rank.Entries) == 0 {
+			t.Errorf("got %+v, want entries", rank)
+		}
+		for _, entry := range rank.Entries {
+			if entry.Pokemon != 663 {
+				t.Errorf("got %d, want %d", entry.Pokemon, 663)
+			}
+		}
+	}
+
+	if _, err := ohbem.QueryPreEvolutionRanks(663, 0, "missing", 1, 0, 15, 15, 1); err != ErrLeagueMissing {
+		t.Errorf("got %v, want %v", err, ErrLeagueMissing)
+	}
+}
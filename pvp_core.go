@@ -65,6 +65,8 @@ func calculatePvPStat(out *PvPRankingStats, stats *PokemonStats, attack, defense
 	if hp < 10 {
 		hp = 10.0
 	}
+	out.Defense = float64(defense+stats.Defense) * multiplier
+	out.Hp = hp
 	out.Value = out.Attack * float64(defense+stats.Defense) * multiplier * hp
 	out.Level = lowest
 	out.Cp = bestCP
@@ -195,7 +197,8 @@ func (sorter compactRankSorter) Swap(i, j int) {
 }
 
 // calculateRanksCompact is optimized (for cache) core method used to calculate PvP ranks for provided Pokemon data.
-func calculateRanksCompact(stats *PokemonStats, cpCap int, lvCap float64, comparator RankingComparator, ivFloor int) (*[4096]int16, *[4096]PvPRankingStats) {
+// It also returns count of valid (under cpCap) combinations.
+func calculateRanksCompact(stats *PokemonStats, cpCap int, lvCap float64, comparator RankingComparator, ivFloor int) (*[4096]int16, *[4096]PvPRankingStats, int) {
 	combinations := new([4096]int16)
 	sorter := compactRankSorter{ranks: new([4096]PvPRankingStats), comparator: comparator}
 
@@ -219,5 +222,14 @@ func calculateRanksCompact(stats *PokemonStats, cpCap int, lvCap float64, compar
 		}
 		combinations[entry.Index] = int16(j + 1)
 	}
-	return combinations, sorter.ranks
+	return combinations, sorter.ranks, sorter.count
+}
+
+// topStatProduct returns the highest stat product of first count ranks, which isn't always rank 1 with non-default comparators.
+func topStatProduct(ranks *[4096]PvPRankingStats, count int) float64 {
+	var top float64
+	for i := 0; i < count; i++ {
+		top = math.Max(top, ranks[i].Value)
+	}
+	return top
 }
@@ -0,0 +1,106 @@
+package gohbem
+
+import "sort"
+
+// SearchPvPRanks Scan every Pokémon, form and temporary evolution in MasterFile for leagues where provided IVs rank at or better than maxRank.
+// Level is the lowest level of the Pokémon (0 means any level). Evolutions are not followed, every species is ranked on its own.
+// Forms sharing stats and types with species or another form are reported once, under the lowest form.
+// Entries are sorted by rank, then by Pokémon, form, evolution and cap.
+func (o *Ohbem) SearchPvPRanks(attack int, defense int, stamina int, level float64, maxRank int16) (map[string][]PokemonEntry, error) {
+	result := make(map[string][]PokemonEntry)
+
+	o.pokemonDataMutex.RLock()
+	defer o.pokemonDataMutex.RUnlock()
+	if err := safetyCheck(o); err != nil {
+		return result, err
+	}
+	if level == 0 {
+		level = 1
+	}
+	if (attack < 0 || attack > 15) || (defense < 0 || defense > 15) || (stamina < 0 || stamina > 15) || level < 1 || maxRank < 1 {
+		return result, ErrQueryInputOutOfRange
+	}
+
+	for pokemonId, masterPokemon := range o.PokemonData.Pokemon {
+		for _, form := range searchForms(&masterPokemon) {
+			ranks, err := o.queryPvPRank(pokemonId, form, 0, 0, attack, defense, stamina, level, QueryOptions{noEvolutions: true}, nil)
+			if err != nil {
+				return result, err
+			}
+			for leagueName, entries := range ranks {
+				for _, entry := range entries {
+					if entry.Rank >= 1 && entry.Rank <= maxRank {
+						result[leagueName] = append(result[leagueName], entry)
+					}
+				}
+			}
+		}
+	}
+
+	for _, entries := range result {
+		sort.Slice(entries, func(i, j int) bool {
+			a, b := &entries[i], &entries[j]
+			if a.Rank != b.Rank {
+				return a.Rank < b.Rank
+			}
+			if a.Pokemon != b.Pokemon {
+				return a.Pokemon < b.Pokemon
+			}
+			if a.Form != b.Form {
+				return a.Form < b.Form
+			}
+			if a.Evolution != b.Evolution {
+				return a.Evolution < b.Evolution
+			}
+			return a.Cap < b.Cap
+		})
+	}
+	return result, nil
+}
+
+// searchForms returns forms of Pokemon with distinct stats and types, form 0 first.
+// Forms without own stats are skipped, unless there is no other form to rank.
+func searchForms(masterPokemon *Pokemon) []int {
+	formIds := make([]int, 0, len(masterPokemon.Forms))
+	for formId := range masterPokemon.Forms {
+		if formId != 0 {
+			formIds = append(formIds, formId)
+		}
+	}
+	sort.Ints(formIds)
+
+	var result []int
+	var seen []PokemonStats
+	var seenLittle []bool
+	add := func(formId int, stats PokemonStats, little bool) {
+		for ix := range seen {
+			if equalStats(seen[ix], stats) && seenLittle[ix] == little {
+				return
+			}
+		}
+		seen = append(seen, stats)
+		seenLittle = append(seenLittle, little)
+		result = append(result, formId)
+	}
+	if masterPokemon.Attack != 0 {
+		add(0, PokemonStats{Attack: masterPokemon.Attack, Defense: masterPokemon.Defense, Stamina: masterPokemon.Stamina, Types: masterPokemon.Types}, masterPokemon.Little)
+	}
+	for _, formId := range formIds {
+		masterForm := masterPokemon.Forms[formId]
+		if masterForm.Attack == 0 {
+			continue
+		}
+		types := masterForm.Types
+		if len(types) == 0 {
+			types = masterPokemon.Types
+		}
+		add(formId, PokemonStats{Attack: masterForm.Attack, Defense: masterForm.Defense, Stamina: masterForm.Stamina, Types: types}, masterForm.Little || masterPokemon.Little)
+	}
+	if len(result) == 0 {
+		if len(formIds) != 0 {
+			return formIds[:1]
+		}
+		return []int{0}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSearchPvPRanks(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	var tests = []struct {
+		a       int
+		d       int
+		s       int
+		level   float64
+		maxRank int16
+	}{
+		{0, 15, 15, 0, 100},
+		{0, 15, 15, 20, 10},
+		{15, 15, 15, 1, 1},
+		{1, 4, 13, 1, 4096},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			result, err := ohbem.SearchPvPRanks(test.a, test.d, test.s, test.level, test.maxRank)
+			if err != nil {
+				t.Errorf("got %v, want nil", err)
+			}
+			found := 0
+			for leagueName, entries := range result {
+				for ex, entry := range entries {
+					found++
+					if entry.Rank < 1 || entry.Rank > test.maxRank {
+						t.Errorf("%s: got rank %d, want at most %d", leagueName, entry.Rank, test.maxRank)
+					}
+					if ex > 0 && entries[ex-1].Rank > entry.Rank {
+						t.Errorf("%s: got rank %d after %d, want ascending", leagueName, entry.Rank, entries[ex-1].Rank)
+					}
+					if test.level > 1 && entry.Level < test.level {
+						t.Errorf("%s: got level %f, want at least %f", leagueName, entry.Level, test.level)
+					}
+					ranks, _ := ohbem.QueryPvPRank(entry.Pokemon, entry.Form, 0, 0, test.a, test.d, test.s, max(test.level, 1))
+					matched := false
+					for _, expected := range ranks[leagueName] {
+						if expected.Pokemon == entry.Pokemon && expected.Form == entry.Form && expected.Evolution == entry.Evolution && expected.Cap == entry.Cap {
+							matched = expected.Rank == entry.Rank
+						}
+					}
+					if !matched {
+						t.Errorf("%s: got %+v, want %+v", leagueName, entry, ranks[leagueName])
+					}
+				}
+			}
+			if found == 0 {
+				t.Errorf("got %v, want entries", result)
+			}
+		})
+	}
+
+	if _, err := ohbem.SearchPvPRanks(16, 15, 15, 1, 100); err != ErrQueryInputOutOfRange {
+		t.Errorf("got %v, want %v", err, ErrQueryInputOutOfRange)
+	}
+}
+
+func TestSearchPvPRanksForms(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	result, err := ohbem.SearchPvPRanks(0, 15, 15, 1, 4096)
+	if err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	forms := make(map[int]map[int]bool)
+	seen := make(map[PokemonEntry]bool)
+	for _, entry := range result["great"] {
+		if forms[entry.Pokemon] == nil {
+			forms[entry.Pokemon] = make(map[int]bool)
+		}
+		forms[entry.Pokemon][entry.Form] = true
+		key := PokemonEntry{Pokemon: entry.Pokemon, Form: entry.Form, Evolution: entry.Evolution, Cap: entry.Cap}
+		if seen[key] {
+			t.Errorf("got duplicate %+v", entry)
+		}
+		seen[key] = true
+	}
+
+	var tests = []struct {
+		pokemonId int
+		forms     map[int]bool
+	}{
+		{3, map[int]bool{0: true}},
+		{20, map[int]bool{0: true, 48: true}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if fmt.Sprint(forms[test.pokemonId]) != fmt.Sprint(test.forms) {
+				t.Errorf("got %v, want %v", forms[test.pokemonId], test.forms)
+			}
+		})
+	}
+}
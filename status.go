@@ -0,0 +1,45 @@
+package gohbem
+
+import (
+	"os"
+	"time"
+)
+
+// MasterFile sources reported by MasterFileStatus.
+const (
+	MasterFileSourceRemote   = "remote"
+	MasterFileSourceCache    = "cache"
+	MasterFileSourceFile     = "file"
+	MasterFileSourceSnapshot = "snapshot"
+	MasterFileSourceEmbedded = "embedded"
+)
+
+// fileModTime returns modification time of file, or current time when it can't be read.
+func fileModTime(filePath string) time.Time {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return time.Now()
+	}
+	return info.ModTime()
+}
+
+func (o *Ohbem) setMasterFileStatus(source string, updatedAt time.Time) {
+	o.masterFileStatusMutex.Lock()
+	defer o.masterFileStatusMutex.Unlock()
+	o.masterFileStatus = MasterFileStatus{Source: source, UpdatedAt: updatedAt}
+}
+
+// MasterFileStatus returns source of PokemonData in memory and when it was updated.
+func (o *Ohbem) MasterFileStatus() MasterFileStatus {
+	o.masterFileStatusMutex.Lock()
+	defer o.masterFileStatusMutex.Unlock()
+	return o.masterFileStatus
+}
+
+// Age returns how long ago PokemonData was updated, zero when nothing was loaded yet.
+func (s MasterFileStatus) Age() time.Duration {
+	if s.UpdatedAt.IsZero() {
+		return 0
+	}
+	return time.Since(s.UpdatedAt)
+}
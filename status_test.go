@@ -0,0 +1,144 @@
+package gohbem
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadCachedPokemonData(t *testing.T) {
+	raw, err := os.ReadFile("./test/master-test.json")
+	if err != nil {
+		t.Fatalf("can't load MasterFile")
+	}
+	remote := loadTestPokemonData(t)
+	delete(remote.Pokemon, 1)
+	source := Ohbem{PokemonData: remote}
+	remotePath := filepath.Join(t.TempDir(), "remote.json")
+	if err := source.SavePokemonData(remotePath); err != nil {
+		t.Fatalf("can't save remote MasterFile")
+	}
+
+	release := make(chan bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		http.ServeFile(w, r, remotePath)
+	}))
+	defer server.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "cache.json")
+	if err := os.WriteFile(cachePath, raw, 0644); err != nil {
+		t.Fatalf("can't write cache")
+	}
+
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileCachePath: cachePath, RemoteMasterFileURL: server.URL}
+	if err := ohbem.LoadCachedPokemonData(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if status := ohbem.MasterFileStatus(); status.Source != MasterFileSourceCache || status.Age() <= 0 {
+		t.Errorf("got %+v, want source %s", status, MasterFileSourceCache)
+	}
+	if _, err := ohbem.QueryPvPRank(1, 0, 0, 1, 15, 15, 15, 1); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(5 * time.Second)
+	for ohbem.MasterFileStatus().Source != MasterFileSourceRemote && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status := ohbem.MasterFileStatus(); status.Source != MasterFileSourceRemote {
+		t.Fatalf("got %+v, want source %s", status, MasterFileSourceRemote)
+	}
+	if _, ok := ohbem.PokemonData.Pokemon[1]; ok {
+		t.Errorf("got Bulbasaur, want remote MasterFile")
+	}
+	cached := Ohbem{}
+	if err := cached.LoadPokemonData(cachePath); err != nil || len(cached.PokemonData.Pokemon) != len(remote.Pokemon) {
+		t.Errorf("got %v %d Pokemon, want %d", err, len(cached.PokemonData.Pokemon), len(remote.Pokemon))
+	}
+}
+
+func TestLoadCachedPokemonDataOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	missing := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileCachePath: filepath.Join(t.TempDir(), "missing.json"), RemoteMasterFileURL: server.URL}
+	if err := missing.LoadCachedPokemonData(); err == nil {
+		t.Errorf("got nil, want error")
+	}
+
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileCachePath: "./test/master-test.json", RemoteMasterFileURL: server.URL}
+	if err := ohbem.LoadCachedPokemonData(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if status := ohbem.MasterFileStatus(); status.Source != MasterFileSourceCache {
+		t.Errorf("got %+v, want source %s", status, MasterFileSourceCache)
+	}
+}
+
+func TestLoadCachedPokemonDataConcurrent(t *testing.T) {
+	remote := loadTestPokemonData(t)
+	delete(remote.Pokemon, 1)
+	source := Ohbem{PokemonData: remote}
+	remotePath := filepath.Join(t.TempDir(), "remote.json")
+	if err := source.SavePokemonData(remotePath); err != nil {
+		t.Fatalf("can't save remote MasterFile")
+	}
+	// every request serves other MasterFile, so each watcher tick replaces PokemonData
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1)%2 == 0 {
+			http.ServeFile(w, r, "./test/master-test.json")
+		} else {
+			http.ServeFile(w, r, remotePath)
+		}
+	}))
+	defer server.Close()
+
+	raw, _ := os.ReadFile("./test/master-test.json")
+	cachePath := filepath.Join(t.TempDir(), "cache.json")
+	if err := os.WriteFile(cachePath, raw, 0644); err != nil {
+		t.Fatalf("can't write cache")
+	}
+
+	// background refresh, watcher and queries share Ohbem, run with -race to catch unguarded PokemonData swaps
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps, MasterFileCachePath: cachePath, RemoteMasterFileURL: server.URL, WatcherInterval: time.Millisecond}
+	if err := ohbem.LoadCachedPokemonData(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if err := ohbem.WatchPokemonData(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	done := make(chan error)
+	for w := 0; w < 4; w++ {
+		go func() {
+			for requests.Load() < 4 {
+				if entries, err := ohbem.QueryPvPRank(661, 0, 0, 1, 0, 15, 15, 1); err != nil || len(entries["great"]) == 0 {
+					done <- fmt.Errorf("got %v %d entries, want great league entries", err, len(entries["great"]))
+					return
+				}
+			}
+			done <- nil
+		}()
+	}
+	for w := 0; w < 4; w++ {
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}
+	if err := ohbem.StopWatchingPokemonData(); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if status := ohbem.MasterFileStatus(); status.Source != MasterFileSourceRemote {
+		t.Errorf("got %+v, want source %s", status, MasterFileSourceRemote)
+	}
+}
@@ -11,12 +11,30 @@ type Ohbem struct {
 	LevelCaps             []int
 	Leagues               map[string]League
 	DisableCache          bool
-	MasterFileCachePath   string // when provided: store there latest changed version of masterfile
-	RankingComparator     RankingComparator
+	MasterFileCachePath   string            // when provided: store there latest changed version of masterfile
+	RemoteMasterFileURL   string            // remote MasterFile address, MasterFileURL when not provided
+	MasterFileHistoryPath string            // when provided: keep versioned snapshots of masterfile in this directory
+	MasterFileHistorySize int               // count of kept snapshots, 10 when not provided
+	RankingComparator     RankingComparator // RankingComparatorDefault when nil, see UseRankingComparator
 	IncludeHundosUnderCap bool
+	ExcludeUnreleased     bool                      // skip unreleased temp evolutions in QueryPvPRank
+	RankBuckets           []RankBucket              // ordered, first matching bucket labels PokemonEntry
+	EvolutionRules        []EvolutionRule           // applied on MasterFile load on top of DefaultEvolutionRules
+	OnMasterFileChange    func(diff MasterFileDiff) // called by watcher after PokemonData is replaced
+	Names                 map[string]NameData       // by locale, see LoadNames
+	Locale                string                    // locale of PokemonName, "en" when not provided
+	HumanReadable         bool                      // fill PokemonEntry.Name
 	WatcherInterval       time.Duration
 	compactRankCache      sync.Map
 	watcherChan           chan bool
+	masterFileStatus      MasterFileStatus
+	masterFileStatusMutex sync.Mutex
+	pokemonDataMutex      sync.RWMutex      // guards PokemonData swaps against running queries
+	refreshMutex          sync.Mutex        // serializes remote MasterFile refreshes
+	keyedComparatorKey    string            // identifies keyedComparator in cache
+	keyedComparator       RankingComparator // set by UseRankingComparator
+	directComparator      RankingComparator // last RankingComparator set directly, see comparatorKey
+	comparatorMutex       sync.Mutex
 	Logger                Logger
 }
 
@@ -39,18 +57,38 @@ type Logger interface {
 }
 
 // League struct is holding one entry of League configuration passed to Ohbem struct.
+// Uncapped leagues (Uncapped set, Cap <= 0 or named LegacyUncappedLeague "master") are ranked with functionally perfect logic instead of CP cap.
+// Custom cup restrictions are optional: empty Allowed lists allow everything, Banned lists always win.
+// Type lists need MasterFile with types (e.g. ConvertGameMaster), Pokemon without types are excluded as DecisionTypesUnknown.
+// LevelCaps overrides Ohbem.LevelCaps for this League, IvFloor excludes IVs below the floor from ranking.
 type League struct {
-	Cap            int  `json:"cap"`
-	LittleCupRules bool `json:"little_cup_rules"`
+	Cap            int             `json:"cap"`
+	LittleCupRules bool            `json:"little_cup_rules"`
+	Uncapped       bool            `json:"uncapped,omitempty"`
+	LevelCaps      []int           `json:"level_caps,omitempty"`
+	IvFloor        int             `json:"iv_floor,omitempty"`
+	AllowedPokemon []LeaguePokemon `json:"allowed_pokemon,omitempty"`
+	BannedPokemon  []LeaguePokemon `json:"banned_pokemon,omitempty"`
+	AllowedTypes   []int           `json:"allowed_types,omitempty"`
+	BannedTypes    []int           `json:"banned_types,omitempty"`
+}
+
+// LeaguePokemon entry represents Pokemon (and optionally Form) used by League allow/ban lists. Form 0 matches every form.
+type LeaguePokemon struct {
+	Pokemon int `json:"pokemon"`
+	Form    int `json:"form,omitempty"`
 }
 
 // PvPRankingStats internal struct for comparison.
+// Attack, Defense and Hp are effective stats (base + IV multiplied by CP multiplier, HP floored).
 type PvPRankingStats struct {
-	Attack float64
-	Value  float64
-	Level  float64
-	Cp     int
-	Index  int
+	Attack  float64
+	Defense float64
+	Hp      float64
+	Value   float64
+	Level   float64
+	Cp      int
+	Index   int
 }
 
 // RankingComparator specifies how to sort rankings.
@@ -72,6 +110,7 @@ type Ranking struct {
 }
 
 // PokemonEntry is holding a row of result for QueryPvPRank and FilterLevelCaps functions.
+// Percentile is Rank relative to Count of valid IV combinations (0.01 means top 1%), Bucket is label of matching RankBuckets entry.
 type PokemonEntry struct {
 	Pokemon    int     `json:"pokemon"`
 	Form       int     `json:"form,omitempty"`
@@ -81,57 +120,427 @@ type PokemonEntry struct {
 	Cp         int     `json:"cp,omitempty"`
 	Percentage float64 `json:"percentage"`
 	Rank       int16   `json:"rank"`
+	Count      int     `json:"count,omitempty"`
+	Percentile float64 `json:"percentile,omitempty"`
+	Bucket     string  `json:"bucket,omitempty"`
 	Capped     bool    `json:"capped,omitempty"`
 	Evolution  int     `json:"evolution,omitempty"`
+	Unreleased bool    `json:"unreleased,omitempty"`  // temp evolution is not released yet
+	FormChange string  `json:"form_change,omitempty"` // kind of form change leading to Form
+	Name       string  `json:"name,omitempty"`        // filled when HumanReadable is set
+}
+
+// QueryOptions adjusts QueryPvPRankWithOptions, nil fields keep Ohbem defaults.
+type QueryOptions struct {
+	ExcludeUnreleased *bool // skip unreleased temp evolutions, nil uses Ohbem.ExcludeUnreleased
+	noEvolutions      bool  // rank queried Pokemon only, without its evolutions and form changes
+	noFormChanges     bool  // don't follow form changes, set when already following one
+}
+
+// QueryDecision explains one decision made by ExplainPvPRank for League (Pokemon entries) or evolution (Target set).
+// Cap is level cap the decision applies to, 0 when it applies to all level caps.
+type QueryDecision struct {
+	Pokemon   int            `json:"pokemon"`
+	Form      int            `json:"form,omitempty"`
+	Evolution int            `json:"evolution,omitempty"`
+	League    string         `json:"league,omitempty"`
+	Cap       float64        `json:"cap,omitempty"`
+	Target    *Evolution     `json:"target,omitempty"`
+	Reason    DecisionReason `json:"reason"`
+}
+
+// EvolutionEdge represents one evolution step from Pokemon form to Target, evaluated by EvolutionTargets.
+type EvolutionEdge struct {
+	Pokemon   int            `json:"pokemon"`
+	Form      int            `json:"form,omitempty"`
+	Target    Evolution      `json:"target"`
+	Reachable bool           `json:"reachable"`
+	Reason    DecisionReason `json:"reason"`
+}
+
+// PreEvolution represents Pokemon form evolving into searched Pokemon in Steps evolutions.
+type PreEvolution struct {
+	Pokemon int `json:"pokemon"`
+	Form    int `json:"form,omitempty"`
+	Steps   int `json:"steps"`
+}
+
+// PreEvolutionRank is holding QueryPvPRank entries of searched Pokemon reached from PreEvolution.
+type PreEvolutionRank struct {
+	PreEvolution
+	Entries []PokemonEntry `json:"entries,omitempty"`
+}
+
+// OwnedPokemon is one Pokemon of player's collection passed to RankInventory.
+// Shadow Pokemon carry their shadow Form, or Form 0 / form without own stats when the species has exactly one shadow Form.
+// Flags are kept for the report and lucky wins ties.
+type OwnedPokemon struct {
+	Pokemon int     `json:"pokemon"`
+	Form    int     `json:"form,omitempty"`
+	Costume int     `json:"costume,omitempty"`
+	Gender  int     `json:"gender,omitempty"`
+	Attack  int     `json:"attack"`
+	Defense int     `json:"defense"`
+	Stamina int     `json:"stamina"`
+	Level   float64 `json:"level"`
+	Shadow  bool    `json:"shadow,omitempty"`
+	Lucky   bool    `json:"lucky,omitempty"`
+}
+
+// InventoryPick is QueryPvPRank entry making OwnedPokemon the best candidate of its target in League.
+type InventoryPick struct {
+	League string `json:"league"`
+	PokemonEntry
+}
+
+// InventoryResult is holding ranks of one OwnedPokemon, Index is its position in RankInventory input.
+type InventoryResult struct {
+	Index   int                       `json:"index"`
+	Owned   OwnedPokemon              `json:"owned"`
+	Best    []InventoryPick           `json:"best,omitempty"`
+	Entries map[string][]PokemonEntry `json:"entries,omitempty"`
+	Err     error                     `json:"-"`
+}
+
+// InventoryReport is holding RankInventory results split into Pokemon worth keeping, transferring and failed queries.
+type InventoryReport struct {
+	Keep     []InventoryResult `json:"keep"`
+	Transfer []InventoryResult `json:"transfer"`
+	Failed   []InventoryResult `json:"failed,omitempty"`
+}
+
+// RankTableQuery selects rank tables exported by RankTable.
+// Zero Pokemon exports all species (with all forms and temp evolutions), empty League all leagues and zero LevelCap all level caps.
+type RankTableQuery struct {
+	Pokemon   int
+	Form      int
+	Evolution int
+	League    string
+	LevelCap  int
+}
+
+// RankTableRow is one IV combination of rank table.
+// Capped is set when the combination keeps its level and rank at every higher level cap, like PokemonEntry.Capped.
+type RankTableRow struct {
+	Pokemon    int
+	Form       int
+	Evolution  int
+	League     string
+	Cap        float64
+	Attack     int
+	Defense    int
+	Stamina    int
+	Level      float64
+	Cp         int
+	Value      float64
+	Percentage float64
+	Rank       int16
+	Capped     bool
+}
+
+// GameMasterNames maps game master names to IDs which are not part of game master itself (they come from protos).
+// Forms missing in Forms are skipped, costumes ending with "_NOEVOLVE" can't evolve.
+type GameMasterNames struct {
+	Forms    map[string]int `json:"forms"`
+	Costumes map[string]int `json:"costumes,omitempty"`
+	Items    map[string]int `json:"items,omitempty"`
+}
+
+// NameData is holding localized names of one locale.
+// Evolutions are temp evolution name formats, with %s replaced by form and species name ("Mega %s X").
+type NameData struct {
+	Pokemon    map[int]string `json:"pokemon"`
+	Forms      map[int]string `json:"forms,omitempty"`
+	Costumes   map[int]string `json:"costumes,omitempty"`
+	Evolutions map[int]string `json:"evolutions,omitempty"`
+}
+
+// SpeciesId identifies Pokemon, form and temp evolution of externally named species.
+type SpeciesId struct {
+	Pokemon   int `json:"pokemon"`
+	Form      int `json:"form,omitempty"`
+	Evolution int `json:"evolution,omitempty"`
+}
+
+// ImportMapping maps names used by pvpoke and pogoapi to IDs.
+// Species keys are pvpoke speciesId ("meowth_alolan") or lowercase pogoapi "<name>_<form>" ("meowth_alola").
+// Base species and megas are resolved from dex number when not mapped, other unmapped forms are reported as unresolved.
+type ImportMapping struct {
+	Species map[string]SpeciesId `json:"species"`
+	Moves   map[string]int       `json:"moves,omitempty"`
+	Items   map[string]int       `json:"items,omitempty"`
+}
+
+// MasterFileStatus reports source of PokemonData in memory.
+// UpdatedAt is time of the last successful remote fetch, or modification time of loaded file.
+type MasterFileStatus struct {
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MasterFileVersion is snapshot of MasterFile kept in MasterFileHistoryPath.
+type MasterFileVersion struct {
+	Fingerprint string    `json:"fingerprint"`
+	FetchedAt   time.Time `json:"fetched_at"`
+	Path        string    `json:"path"`
+}
+
+// PokemonForm identifies Pokemon form in MasterFileDiff, Form 0 is Pokemon itself.
+type PokemonForm struct {
+	Pokemon int `json:"pokemon"`
+	Form    int `json:"form,omitempty"`
+}
+
+// StatChange is base stats change of Pokemon form.
+type StatChange struct {
+	PokemonForm
+	Old PokemonStats `json:"old"`
+	New PokemonStats `json:"new"`
+}
+
+// TempEvolutionChange is added (Old is nil), removed (New is nil) or changed temp evolution of Pokemon form.
+type TempEvolutionChange struct {
+	PokemonForm
+	Evolution int           `json:"evolution"`
+	Old       *PokemonStats `json:"old,omitempty"`
+	New       *PokemonStats `json:"new,omitempty"`
+}
+
+// EvolutionChange is changed evolutions of Pokemon form.
+type EvolutionChange struct {
+	PokemonForm
+	Old []Evolution `json:"old,omitempty"`
+	New []Evolution `json:"new,omitempty"`
+}
+
+// MasterFileDiff is holding changes between two PokemonData returned by DiffPokemonData.
+// ReleaseChanges lists temp evolutions present in both versions with changed Unreleased flag.
+type MasterFileDiff struct {
+	AddedPokemon         []int                 `json:"added_pokemon,omitempty"`
+	RemovedPokemon       []int                 `json:"removed_pokemon,omitempty"`
+	AddedForms           []PokemonForm         `json:"added_forms,omitempty"`
+	RemovedForms         []PokemonForm         `json:"removed_forms,omitempty"`
+	StatChanges          []StatChange          `json:"stat_changes,omitempty"`
+	TempEvolutionChanges []TempEvolutionChange `json:"temp_evolution_changes,omitempty"`
+	ReleaseChanges       []TempEvolutionChange `json:"release_changes,omitempty"`
+	EvolutionChanges     []EvolutionChange     `json:"evolution_changes,omitempty"`
+}
+
+// DecisionReason describes why QueryPvPRank included, capped or skipped an entry.
+type DecisionReason string
+
+// RankBucket is holding label assigned to PokemonEntry with Rank <= MaxRank or Percentile <= MaxPercentile.
+// Zero MaxRank or MaxPercentile is ignored.
+type RankBucket struct {
+	Label         string  `json:"label"`
+	MaxRank       int16   `json:"max_rank,omitempty"`
+	MaxPercentile float64 `json:"max_percentile,omitempty"`
+}
+
+// Opponent represents target Pokemon used by AnalyzeBreakpoints.
+type Opponent = BattlePokemon
+
+// BattlePokemon represents single Pokemon with its IVs and level.
+type BattlePokemon struct {
+	Pokemon   int     `json:"pokemon"`
+	Form      int     `json:"form,omitempty"`
+	Evolution int     `json:"evolution,omitempty"`
+	Attack    int     `json:"attack"`
+	Defense   int     `json:"defense"`
+	Stamina   int     `json:"stamina"`
+	Level     float64 `json:"level"`
+}
+
+// Combatant represents BattlePokemon with its moveset and shields used by SimulateBattle.
+type Combatant struct {
+	BattlePokemon
+	FastMove     int   `json:"fast_move"`
+	ChargedMoves []int `json:"charged_moves"`
+	Shields      int   `json:"shields"`
+}
+
+// BattleEvent represents one move used during SimulateBattle.
+type BattleEvent struct {
+	Turn     int  `json:"turn"`
+	Attacker int  `json:"attacker"`
+	Move     int  `json:"move"`
+	Charged  bool `json:"charged,omitempty"`
+	Damage   int  `json:"damage"`
+	Shielded bool `json:"shielded,omitempty"`
+	Energy   int  `json:"energy"`
+	Hp       int  `json:"hp"`
+}
+
+// BattleResult is holding result of SimulateBattle. Winner is index of winning Combatant, or -1 on tie.
+type BattleResult struct {
+	Winner   int           `json:"winner"`
+	Hp       [2]int        `json:"hp"`
+	MaxHp    [2]int        `json:"max_hp"`
+	Turns    int           `json:"turns"`
+	Timeline []BattleEvent `json:"timeline"`
+}
+
+// BreakpointQuery is holding league and move details used by AnalyzeBreakpoints.
+// Cap is level cap of league ranks compared spreads are from, 0 compares all spreads at analysed Pokemon level.
+// Power is used by analysed Pokemon (breakpoints), OpponentPower by opponents (bulkpoints).
+// Multipliers combine STAB and type effectiveness, 0 is treated as 1.
+type BreakpointQuery struct {
+	League             string  `json:"league"`
+	Cap                float64 `json:"cap,omitempty"`
+	Power              int     `json:"power"`
+	Multiplier         float64 `json:"multiplier,omitempty"`
+	OpponentPower      int     `json:"opponent_power"`
+	OpponentMultiplier float64 `json:"opponent_multiplier,omitempty"`
+}
+
+// IvSpread represents IV combination together with its level, CP and rank under league cap.
+type IvSpread struct {
+	Attack  int     `json:"attack"`
+	Defense int     `json:"defense"`
+	Stamina int     `json:"stamina"`
+	Level   float64 `json:"level"`
+	Cp      int     `json:"cp"`
+	Rank    int16   `json:"rank,omitempty"`
+}
+
+// DamageTier is holding IV spreads dealing (breakpoints) or taking (bulkpoints) the same Damage.
+// Stat is the lowest effective attack (breakpoints) or defense (bulkpoints) among Spreads, threshold of the tier.
+type DamageTier struct {
+	Damage  int        `json:"damage"`
+	Stat    float64    `json:"stat"`
+	Spreads []IvSpread `json:"spreads"`
+}
+
+// BreakpointReport is holding result of AnalyzeBreakpoints for one Opponent.
+// Breakpoints are ordered from the highest damage dealt, Bulkpoints from the lowest damage taken,
+// both are empty when no spread is valid under league cap. Breakpoint and Bulkpoint are set when queried IVs reach the first tier.
+type BreakpointReport struct {
+	Opponent        Opponent     `json:"opponent"`
+	OpponentAttack  float64      `json:"opponent_attack"`
+	OpponentDefense float64      `json:"opponent_defense"`
+	Attack          float64      `json:"attack"`
+	Defense         float64      `json:"defense"`
+	Damage          int          `json:"damage"`
+	DamageTaken     int          `json:"damage_taken"`
+	Breakpoint      bool         `json:"breakpoint"`
+	Bulkpoint       bool         `json:"bulkpoint"`
+	Breakpoints     []DamageTier `json:"breakpoints,omitempty"`
+	Bulkpoints      []DamageTier `json:"bulkpoints,omitempty"`
 }
 
 // Pokemon entry represents row of Pokemon data from MasterFile
 type Pokemon struct {
+	Name                      string               `json:"name,omitempty"`
 	Attack                    int                  `json:"attack"`
 	Defense                   int                  `json:"defense"`
 	Stamina                   int                  `json:"stamina"`
 	Little                    bool                 `json:"little,omitempty"`
+	Types                     []int                `json:"types,omitempty"`
+	FastMoves                 []int                `json:"fast_moves,omitempty"`
+	ChargedMoves              []int                `json:"charged_moves,omitempty"`
 	Evolutions                []Evolution          `json:"evolutions,omitempty"`
 	TempEvolutions            map[int]PokemonStats `json:"temp_evolutions,omitempty"`
 	CostumeOverrideEvolutions []int                `json:"costume_override_evos,omitempty"`
+	FormChanges               []FormChange         `json:"form_changes,omitempty"`
 	Forms                     map[int]Form         `json:"forms"`
 }
 
 // Form entry represents row of Pokemon -> Form.
 type Form struct {
+	Name                      string               `json:"name,omitempty"`
 	Attack                    int                  `json:"attack,omitempty"`
 	Defense                   int                  `json:"defense,omitempty"`
 	Stamina                   int                  `json:"stamina,omitempty"`
 	Little                    bool                 `json:"little,omitempty"`
+	Shadow                    bool                 `json:"shadow,omitempty"` // shadow form, used by RankInventory
+	Types                     []int                `json:"types,omitempty"`
+	FastMoves                 []int                `json:"fast_moves,omitempty"`
+	ChargedMoves              []int                `json:"charged_moves,omitempty"`
 	Evolutions                []Evolution          `json:"evolutions,omitempty"`
 	TempEvolutions            map[int]PokemonStats `json:"temp_evolutions,omitempty"`
 	CostumeOverrideEvolutions []int                `json:"costume_override_evos,omitempty"`
+	FormChanges               []FormChange         `json:"form_changes,omitempty"`
 }
 
 // Evolution entry represents row of Pokemon -> Evolution.
 type Evolution struct {
-	Pokemon           int `json:"pokemon"`
-	Form              int `json:"form,omitempty"`
-	GenderRequirement int `json:"gender_requirement,omitempty"`
+	Pokemon           int                  `json:"pokemon"`
+	Form              int                  `json:"form,omitempty"`
+	GenderRequirement int                  `json:"gender_requirement,omitempty"`
+	Conditions        []EvolutionCondition `json:"conditions,omitempty"`
+}
+
+// FormChange entry represents transition of Pokemon form into another form of the same Pokemon, which isn't an evolution.
+type FormChange struct {
+	Form    int    `json:"form"`
+	Kind    string `json:"kind"`              // see FormChange* kinds
+	Item    int    `json:"item,omitempty"`    // item needed to change form
+	Partner int    `json:"partner,omitempty"` // Pokemon fused with for FormChangeFusion
+}
+
+// EvolutionCondition entry represents special requirement of Evolution, see EvolutionCondition* kinds.
+type EvolutionCondition struct {
+	Kind      string `json:"kind"`
+	Stat      string `json:"stat,omitempty"`
+	Gender    int    `json:"gender,omitempty"`
+	Item      int    `json:"item,omitempty"`
+	TimeOfDay string `json:"time_of_day,omitempty"`
+}
+
+// EvolutionRule entry represents supplementary Conditions applied to Pokemon (Form 0 means all forms) -> Target evolutions.
+type EvolutionRule struct {
+	Pokemon    int                  `json:"pokemon"`
+	Form       int                  `json:"form,omitempty"`
+	Target     int                  `json:"target"`
+	TargetForm int                  `json:"target_form,omitempty"`
+	Conditions []EvolutionCondition `json:"conditions"`
 }
 
 // PokemonStats entry represents basic Pokemon stats and mega release state.
 type PokemonStats struct {
-	Attack     int  `json:"attack,omitempty"`
-	Defense    int  `json:"defense,omitempty"`
-	Stamina    int  `json:"stamina,omitempty"`
-	Unreleased bool `json:"unreleased,omitempty"`
+	Attack     int   `json:"attack,omitempty"`
+	Defense    int   `json:"defense,omitempty"`
+	Stamina    int   `json:"stamina,omitempty"`
+	Unreleased bool  `json:"unreleased,omitempty"`
+	Types      []int `json:"types,omitempty"` // temp evolution types, when they differ from form types
 }
 
 // PokemonData is a struct holding MasterFile data.
+// Moves and TypeEffectiveness are optional, default type chart is used when TypeEffectiveness is missing.
 type PokemonData struct {
-	Initialized bool            `json:"-"`
-	Pokemon     map[int]Pokemon `json:"pokemon"`
-	Costumes    map[int]bool    `json:"costumes"`
+	Initialized       bool                    `json:"-"`
+	Pokemon           map[int]Pokemon         `json:"pokemon"`
+	Costumes          map[int]bool            `json:"costumes"`
+	Moves             map[int]Move            `json:"moves,omitempty"`
+	TypeEffectiveness map[int]map[int]float64 `json:"type_effectiveness,omitempty"`
+	preEvolutions     map[[2]int][]PreEvolution
+	raw               []byte // MasterFile as loaded, before EvolutionRules are applied
+}
+
+// Move entry represents PvP move from MasterFile.
+// Energy is gained by fast moves (Turns > 0) and spent by charged moves.
+type Move struct {
+	Name   string `json:"name,omitempty"`
+	Type   int    `json:"type"`
+	Power  int    `json:"power"`
+	Energy int    `json:"energy"`
+	Turns  int    `json:"turns,omitempty"`
+}
+
+// compactCacheKey is identifying compactCacheValue for provided stats, cpCap, level caps and IV floor.
+type compactCacheKey struct {
+	Cap        int
+	Attack     int
+	Defense    int
+	Stamina    int
+	IvFloor    int
+	LevelCaps  [2]uint64
+	Comparator string
 }
 
-// compactCacheValue is holding Combinations and TopValue for provided stats and cpCap.
+// compactCacheValue is holding Combinations, TopValue and Count of valid combinations for provided stats and cpCap.
 type compactCacheValue struct {
 	Combinations *[4096]int16
 	TopValue     float64
+	Count        int
 }
@@ -0,0 +1,35 @@
+package gohbem
+
+// Temp evolution IDs, following HoloTemporaryEvolutionId used by the MasterFile.
+const (
+	TempEvolutionMega   = 1
+	TempEvolutionMegaX  = 2
+	TempEvolutionMegaY  = 3
+	TempEvolutionPrimal = 4
+)
+
+// Temp evolution kinds returned by TempEvolutionKind.
+const (
+	TempEvolutionKindMega   = "mega"
+	TempEvolutionKindMegaX  = "mega_x"
+	TempEvolutionKindMegaY  = "mega_y"
+	TempEvolutionKindPrimal = "primal"
+)
+
+// tempEvolutionKinds maps temp evolution IDs to their kinds.
+var tempEvolutionKinds = map[int]string{
+	TempEvolutionMega:   TempEvolutionKindMega,
+	TempEvolutionMegaX:  TempEvolutionKindMegaX,
+	TempEvolutionMegaY:  TempEvolutionKindMegaY,
+	TempEvolutionPrimal: TempEvolutionKindPrimal,
+}
+
+// TempEvolutionKind Return kind of temp evolution ID, empty string for base form and unknown IDs.
+func TempEvolutionKind(evolution int) string {
+	return tempEvolutionKinds[evolution]
+}
+
+// IsPrimal Check whether temp evolution ID is Primal Reversion rather than Mega Evolution.
+func IsPrimal(evolution int) bool {
+	return evolution == TempEvolutionPrimal
+}
@@ -0,0 +1,84 @@
+package gohbem
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTempEvolutionKind(t *testing.T) {
+	var tests = []struct {
+		evolution int
+		expected  string
+	}{
+		{0, ""},
+		{TempEvolutionMega, TempEvolutionKindMega},
+		{TempEvolutionMegaX, TempEvolutionKindMegaX},
+		{TempEvolutionMegaY, TempEvolutionKindMegaY},
+		{TempEvolutionPrimal, TempEvolutionKindPrimal},
+		{99, ""},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			if kind := TempEvolutionKind(test.evolution); kind != test.expected {
+				t.Errorf("got %q, want %q", kind, test.expected)
+			}
+		})
+	}
+}
+
+func TestQueryPvPRankUnreleased(t *testing.T) {
+	ohbem := Ohbem{Leagues: leagues, LevelCaps: levelCaps}
+	err := ohbem.LoadPokemonData("./test/master-test.json")
+	if err != nil {
+		t.Errorf("can't load MasterFile")
+	}
+
+	include, exclude := false, true
+	var tests = []struct {
+		form              int
+		defaultExclude    bool
+		excludeUnreleased *bool
+		evolutions        map[int]bool
+	}{
+		{0, false, &include, map[int]bool{0: false, TempEvolutionMegaX: true, TempEvolutionMegaY: true}},
+		{0, false, &exclude, map[int]bool{0: false}},
+		{135, false, &include, map[int]bool{0: false, TempEvolutionMegaX: true, TempEvolutionMegaY: true}},
+		{135, false, &exclude, map[int]bool{0: false}},
+		{0, false, nil, map[int]bool{0: false, TempEvolutionMegaX: true, TempEvolutionMegaY: true}},
+		{0, true, nil, map[int]bool{0: false}},
+		{0, true, &include, map[int]bool{0: false, TempEvolutionMegaX: true, TempEvolutionMegaY: true}},
+	}
+
+	for ix, test := range tests {
+		testName := fmt.Sprintf("%d", ix)
+		t.Run(testName, func(t *testing.T) {
+			ohbem.ExcludeUnreleased = test.defaultExclude
+			result, err := ohbem.QueryPvPRankWithOptions(150, test.form, 0, 0, 15, 15, 15, 1, QueryOptions{ExcludeUnreleased: test.excludeUnreleased})
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			evolutions := make(map[int]bool)
+			for _, entry := range result["great"] {
+				evolutions[entry.Evolution] = entry.Unreleased
+			}
+			if fmt.Sprint(evolutions) != fmt.Sprint(test.evolutions) {
+				t.Errorf("got %v, want %v", evolutions, test.evolutions)
+			}
+		})
+	}
+
+	ohbem.ExcludeUnreleased = true
+	_, decisions, err := ohbem.ExplainPvPRank(150, 0, 0, 0, 15, 15, 15, 1)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	expected := QueryDecision{Pokemon: 150, Evolution: TempEvolutionMegaX, Reason: DecisionUnreleased}
+	for _, decision := range decisions {
+		if decision == expected {
+			return
+		}
+	}
+	t.Errorf("decisions are missing %+v", expected)
+}
@@ -0,0 +1,95 @@
+package gohbem
+
+// Pokemon types, following HoloPokemonType IDs used by the MasterFile.
+const (
+	TypeNormal   = 1
+	TypeFighting = 2
+	TypeFlying   = 3
+	TypePoison   = 4
+	TypeGround   = 5
+	TypeRock     = 6
+	TypeBug      = 7
+	TypeGhost    = 8
+	TypeSteel    = 9
+	TypeFire     = 10
+	TypeWater    = 11
+	TypeGrass    = 12
+	TypeElectric = 13
+	TypePsychic  = 14
+	TypeIce      = 15
+	TypeDragon   = 16
+	TypeDark     = 17
+	TypeFairy    = 18
+)
+
+// Type effectiveness multipliers used in Pokemon GO.
+const (
+	EffectivenessSuper   = 1.6
+	EffectivenessNotVery = 0.625
+	EffectivenessImmune  = 0.390625
+	EffectivenessNeutral = 1.0
+	stabMultiplier       = 1.2
+)
+
+// defaultTypeEffectiveness is holding attacking type -> defending type multipliers, neutral pairs are omitted.
+var defaultTypeEffectiveness = buildTypeEffectiveness(map[int][3][]int{
+	// attacking type: {super effective, not very effective, immune}
+	TypeNormal:   {nil, {TypeRock, TypeSteel}, {TypeGhost}},
+	TypeFighting: {{TypeNormal, TypeRock, TypeSteel, TypeIce, TypeDark}, {TypeFlying, TypePoison, TypeBug, TypePsychic, TypeFairy}, {TypeGhost}},
+	TypeFlying:   {{TypeFighting, TypeBug, TypeGrass}, {TypeRock, TypeSteel, TypeElectric}, nil},
+	TypePoison:   {{TypeGrass, TypeFairy}, {TypePoison, TypeGround, TypeRock, TypeGhost}, {TypeSteel}},
+	TypeGround:   {{TypePoison, TypeRock, TypeSteel, TypeFire, TypeElectric}, {TypeBug, TypeGrass}, {TypeFlying}},
+	TypeRock:     {{TypeFlying, TypeBug, TypeFire, TypeIce}, {TypeFighting, TypeGround, TypeSteel}, nil},
+	TypeBug:      {{TypeGrass, TypePsychic, TypeDark}, {TypeFighting, TypeFlying, TypePoison, TypeGhost, TypeSteel, TypeFire, TypeFairy}, nil},
+	TypeGhost:    {{TypeGhost, TypePsychic}, {TypeDark}, {TypeNormal}},
+	TypeSteel:    {{TypeRock, TypeIce, TypeFairy}, {TypeSteel, TypeFire, TypeWater, TypeElectric}, nil},
+	TypeFire:     {{TypeBug, TypeSteel, TypeGrass, TypeIce}, {TypeRock, TypeFire, TypeWater, TypeDragon}, nil},
+	TypeWater:    {{TypeGround, TypeRock, TypeFire}, {TypeWater, TypeGrass, TypeDragon}, nil},
+	TypeGrass:    {{TypeGround, TypeRock, TypeWater}, {TypeFlying, TypePoison, TypeBug, TypeSteel, TypeFire, TypeGrass, TypeDragon}, nil},
+	TypeElectric: {{TypeFlying, TypeWater}, {TypeGrass, TypeElectric, TypeDragon}, {TypeGround}},
+	TypePsychic:  {{TypeFighting, TypePoison}, {TypeSteel, TypePsychic}, {TypeDark}},
+	TypeIce:      {{TypeFlying, TypeGround, TypeGrass, TypeDragon}, {TypeSteel, TypeFire, TypeWater, TypeIce}, nil},
+	TypeDragon:   {{TypeDragon}, {TypeSteel}, {TypeFairy}},
+	TypeDark:     {{TypeGhost, TypePsychic}, {TypeFighting, TypeDark, TypeFairy}, nil},
+	TypeFairy:    {{TypeFighting, TypeDragon, TypeDark}, {TypePoison, TypeSteel, TypeFire}, nil},
+})
+
+func buildTypeEffectiveness(chart map[int][3][]int) map[int]map[int]float64 {
+	result := make(map[int]map[int]float64)
+	multipliers := [3]float64{EffectivenessSuper, EffectivenessNotVery, EffectivenessImmune}
+	for attackType, groups := range chart {
+		result[attackType] = make(map[int]float64)
+		for ix, group := range groups {
+			for _, defenseType := range group {
+				result[attackType][defenseType] = multipliers[ix]
+			}
+		}
+	}
+	return result
+}
+
+// DefaultTypeEffectiveness returns copy of built-in type chart (attacking type -> defending type -> multiplier).
+func DefaultTypeEffectiveness() map[int]map[int]float64 {
+	result := make(map[int]map[int]float64, len(defaultTypeEffectiveness))
+	for attackType, row := range defaultTypeEffectiveness {
+		result[attackType] = make(map[int]float64, len(row))
+		for defenseType, multiplier := range row {
+			result[attackType][defenseType] = multiplier
+		}
+	}
+	return result
+}
+
+// calculateTypeEffectiveness multiplies effectiveness of attacking type against every defending type.
+func calculateTypeEffectiveness(chart map[int]map[int]float64, attackType int, defenseTypes []int) float64 {
+	if len(chart) == 0 {
+		chart = defaultTypeEffectiveness
+	}
+	multiplier := EffectivenessNeutral
+	for _, defenseType := range defenseTypes {
+		if m, ok := chart[attackType][defenseType]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
@@ -3,6 +3,7 @@ package gohbem
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
 )
@@ -24,8 +25,22 @@ func containsInt(slice []int, value int) bool {
 	return false
 }
 
-func fetchMasterFile() (PokemonData, error) {
-	req, err := http.NewRequest("GET", MasterFileURL, nil)
+// levelCapsMask packs level caps (up to MaxLevel) into bitmask usable as a part of cache key.
+func levelCapsMask(levelCaps []int) [2]uint64 {
+	var mask [2]uint64
+	for _, lvCap := range levelCaps {
+		if lvCap >= 0 && lvCap <= MaxLevel {
+			mask[lvCap/64] |= 1 << (lvCap % 64)
+		}
+	}
+	return mask
+}
+
+func fetchMasterFile(url string) (PokemonData, error) {
+	if url == "" {
+		url = MasterFileURL
+	}
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return PokemonData{}, ErrMasterFileFetch
 	}
@@ -39,12 +54,16 @@ func fetchMasterFile() (PokemonData, error) {
 	//goland:noinspection GoUnhandledErrorResult
 	defer resp.Body.Close()
 
-	var data PokemonData
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	raw, err := io.ReadAll(resp.Body)
 	if err != nil {
+		return PokemonData{}, ErrMasterFileFetch
+	}
+	var data PokemonData
+	if err := json.Unmarshal(raw, &data); err != nil {
 		return PokemonData{}, ErrMasterFileDecode
 	}
 	data.Initialized = true
+	data.raw = raw
 	return data, nil
 }
 
@@ -56,7 +75,11 @@ func safetyCheck(o *Ohbem) error {
 		return ErrLeaguesMissing
 	}
 	if len(o.LevelCaps) == 0 {
-		return ErrLevelCapsMissing
+		for _, league := range o.Leagues {
+			if len(league.LevelCaps) == 0 {
+				return ErrLevelCapsMissing
+			}
+		}
 	}
 	return nil
 }